@@ -22,6 +22,7 @@ import (
 
 	"github.com/palantir/amalgomate/amalgomated"
 	"github.com/palantir/godel/v2/framework/pluginapi"
+	"github.com/palantir/okgo/checker/lineparser"
 	"github.com/palantir/okgo/okgo"
 	"github.com/pkg/errors"
 )
@@ -48,9 +49,18 @@ func ParamMultiCPU(multiCPU okgo.CheckerMultiCPU) AmalgomatedCheckerParam {
 	})
 }
 
+// ParamLineParserWithWd specifies a function that converts each line of output into an issue. It is equivalent to
+// ParamLineParser(lineparser.FromFunc(lineParserWithWd)).
 func ParamLineParserWithWd(lineParserWithWd func(line, wd string) okgo.Issue) AmalgomatedCheckerParam {
+	return ParamLineParser(lineparser.FromFunc(lineParserWithWd))
+}
+
+// ParamLineParser specifies the factory for the LineParser used to parse the output of each invocation of the check.
+// The parsers in the lineparser package support the output formats of common Go tools. If multiple line parsers are
+// specified, the last one is used. If no line parser is specified, lineparser.Default is used.
+func ParamLineParser(newLineParser lineparser.Factory) AmalgomatedCheckerParam {
 	return paramFunc(func(c *amalgomatedChecker) {
-		c.lineParserWithWd = lineParserWithWd
+		c.newLineParser = newLineParser
 	})
 }
 
@@ -68,8 +78,8 @@ func ParamArgs(args ...string) AmalgomatedCheckerParam {
 
 func NewAmalgomatedChecker(typeName okgo.CheckerType, params ...AmalgomatedCheckerParam) okgo.Checker {
 	checker := &amalgomatedChecker{
-		typeName:         typeName,
-		lineParserWithWd: okgo.NewIssueFromLine,
+		typeName:      typeName,
+		newLineParser: lineparser.Default,
 	}
 	for _, p := range params {
 		if p == nil {
@@ -84,7 +94,7 @@ type amalgomatedChecker struct {
 	typeName              okgo.CheckerType
 	priority              okgo.CheckerPriority
 	multiCPU              okgo.CheckerMultiCPU
-	lineParserWithWd      func(line, wd string) okgo.Issue
+	newLineParser         lineparser.Factory
 	includeProjectDirFlag bool
 	args                  []string
 }
@@ -113,9 +123,7 @@ func (c *amalgomatedChecker) Check(pkgPaths []string, projectDir string, stdout
 	if cmd == nil {
 		return
 	}
-	RunCommandAndStreamOutput(cmd, func(line string) okgo.Issue {
-		return c.lineParserWithWd(line, wd)
-	}, stdout)
+	RunCommandAndStreamWithParser(cmd, c.newLineParser(wd), stdout)
 }
 
 func (c *amalgomatedChecker) RunCheckCmd(args []string, stdout io.Writer) {
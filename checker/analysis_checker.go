@@ -0,0 +1,405 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/analysis"
+	analysischecker "golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+const analysisLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports |
+	packages.NeedDeps | packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo |
+	packages.NeedModule
+
+// NewAnalysisChecker returns a checker that runs the provided analyzers in-process on the packages that are checked
+// (including their tests). When the checker is run as part of a run of okgo checks, the packages are loaded and
+// type-checked once per run: all of the analysis checkers in the run that check the same package share the loaded
+// package. Each diagnostic reported by an analyzer is reported as an issue whose Rule is the name of the analyzer and
+// that includes the suggested fixes of the diagnostic. Errors encountered while loading the packages are also reported
+// as issues. The type of the checker is the names of the analyzers joined by ",": an asset reports the type of its
+// creator instead, so the type of the checker only matters when it is run directly.
+func NewAnalysisChecker(analyzers ...*analysis.Analyzer) okgo.Checker {
+	return &analysisChecker{
+		analyzers: analyzers,
+	}
+}
+
+type analysisChecker struct {
+	analyzers []*analysis.Analyzer
+}
+
+func (c *analysisChecker) Type() (okgo.CheckerType, error) {
+	var names []string
+	for _, analyzer := range c.analyzers {
+		names = append(names, analyzer.Name)
+	}
+	return okgo.CheckerType(strings.Join(names, ",")), nil
+}
+
+func (c *analysisChecker) Priority() (okgo.CheckerPriority, error) {
+	return 0, nil
+}
+
+func (c *analysisChecker) MultiCPU() (okgo.CheckerMultiCPU, error) {
+	return true, nil
+}
+
+func (c *analysisChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	c.CheckWithContext(context.Background(), pkgPaths, projectDir, stdout)
+}
+
+// CheckWithContext runs the check in the same manner as Check. If the provided context carries an okgo.RunCache, the
+// packages are loaded using the package cache of the run.
+func (c *analysisChecker) CheckWithContext(
+	ctx context.Context,
+	pkgPaths []string,
+	projectDir string,
+	stdout io.Writer) {
+	issues, err := c.analyze(runPackageCache(ctx), pkgPaths)
+	if err != nil {
+		okgo.WriteErrorAsIssue(err, stdout)
+		return
+	}
+	for _, issue := range issues {
+		issueJSONBytes, err := json.Marshal(issue)
+		if err != nil {
+			okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to marshal issue %+v as JSON", issue), stdout)
+			continue
+		}
+		_, _ = fmt.Fprintln(stdout, string(issueJSONBytes))
+	}
+}
+
+// RunCheckCmd runs the analyzers on the packages specified by the provided arguments and writes each issue on its own
+// line in the form "path:line:col: content".
+func (c *analysisChecker) RunCheckCmd(args []string, stdout io.Writer) {
+	issues, err := c.analyze(newPackageCache(), args)
+	if err != nil {
+		_, _ = fmt.Fprintln(stdout, err.Error())
+		return
+	}
+	for _, issue := range issues {
+		_, _ = fmt.Fprintln(stdout, issue.String())
+	}
+}
+
+// analyze runs the analyzers on the specified packages, which are loaded using the provided cache, and returns the
+// issues that they report in order of path and position. Returns an error if the packages cannot be loaded or analyzed.
+func (c *analysisChecker) analyze(cache *packageCache, pkgPaths []string) ([]okgo.Issue, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to determine working directory")
+	}
+	pkgs, err := cache.load(wd, pkgPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := newIssueSet()
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, pkgErr := range pkg.Errors {
+			issues.add(okgo.NewIssueFromLine(pkgErr.Error(), wd))
+		}
+	})
+
+	graph, err := analysischecker.Analyze(c.analyzers, pkgs, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run analyzers")
+	}
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			if len(act.Package.Errors) > 0 {
+				// analysis is skipped for packages with errors, which have already been reported
+				continue
+			}
+			err := errors.Wrapf(act.Err, "analyzer %s failed on package %s", act.Analyzer.Name, act.Package.PkgPath)
+			issues.add(okgo.Issue{
+				Content: err.Error(),
+				Rule:    act.Analyzer.Name,
+			})
+			continue
+		}
+		for _, diagnostic := range act.Diagnostics {
+			issues.add(newIssueFromDiagnostic(act.Analyzer, act.Package.Fset, diagnostic, wd))
+		}
+	}
+	return issues.sorted(), nil
+}
+
+// packageCacheKey is the key of the package cache in an okgo.RunCache.
+type packageCacheKey struct{}
+
+// runPackageCache returns the package cache of the run whose okgo.RunCache is carried by the provided context. If the
+// context does not carry a RunCache, returns a new package cache.
+func runPackageCache(ctx context.Context) *packageCache {
+	runCache := okgo.RunCacheFromContext(ctx)
+	if runCache == nil {
+		return newPackageCache()
+	}
+	return runCache.Value(packageCacheKey{}, func() interface{} {
+		return newPackageCache()
+	}).(*packageCache)
+}
+
+// packageCache loads and type-checks packages for analysis. Packages specified as relative directory paths are cached
+// per directory: the packages that are not yet cached are loaded together, and loads of a package that is already being
+// loaded wait for that load to complete and share its result. Other patterns (such as import paths or patterns that
+// contain "...") are loaded every time. It is safe for concurrent use.
+type packageCache struct {
+	lock    sync.Mutex
+	entries map[string]*packageCacheEntry
+}
+
+// packageCacheEntry is the result of loading the package in a directory (along with its test variants). done is closed
+// once the package is loaded.
+type packageCacheEntry struct {
+	done chan struct{}
+	pkgs []*packages.Package
+	err  error
+}
+
+func newPackageCache() *packageCache {
+	return &packageCache{
+		entries: make(map[string]*packageCacheEntry),
+	}
+}
+
+// load returns the packages (including their tests) for the provided package paths relative to wd. The returned
+// packages are shared and must not be modified.
+func (c *packageCache) load(wd string, pkgPaths []string) ([]*packages.Package, error) {
+	var (
+		toLoad   []string
+		uncached []string
+		loading  = make(map[string]*packageCacheEntry)
+		entries  []*packageCacheEntry
+		seen     = make(map[string]struct{})
+	)
+	c.lock.Lock()
+	for _, pkgPath := range pkgPaths {
+		dir, ok := pkgDir(wd, pkgPath)
+		if !ok {
+			uncached = append(uncached, pkgPath)
+			continue
+		}
+		if _, ok := seen[dir]; ok {
+			continue
+		}
+		seen[dir] = struct{}{}
+		entry, ok := c.entries[dir]
+		if !ok {
+			entry = &packageCacheEntry{
+				done: make(chan struct{}),
+			}
+			c.entries[dir] = entry
+			loading[dir] = entry
+			toLoad = append(toLoad, pkgPath)
+		}
+		entries = append(entries, entry)
+	}
+	c.lock.Unlock()
+
+	var pkgs []*packages.Package
+	if len(toLoad) > 0 || len(uncached) > 0 {
+		loaded, err := packages.Load(&packages.Config{
+			Mode:  analysisLoadMode,
+			Dir:   wd,
+			Tests: true,
+		}, append(toLoad, uncached...)...)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to load packages")
+		}
+		for _, pkg := range loaded {
+			if entry, ok := loading[loadedPkgDir(wd, pkg)]; ok {
+				entry.pkgs = append(entry.pkgs, pkg)
+			} else if len(uncached) > 0 {
+				pkgs = append(pkgs, pkg)
+			}
+			// otherwise, the package is the generated main package of a test, which is not analyzed
+		}
+		for _, entry := range loading {
+			entry.err = err
+			close(entry.done)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, entry := range entries {
+		<-entry.done
+		if entry.err != nil {
+			return nil, entry.err
+		}
+		pkgs = append(pkgs, entry.pkgs...)
+	}
+	return pkgs, nil
+}
+
+// pkgDir returns the directory of the provided package path if it is a relative directory path (such as "." or
+// "./foo"). Returns false if the package path is any other pattern.
+func pkgDir(wd, pkgPath string) (string, bool) {
+	if pkgPath != "." && pkgPath != ".." && !strings.HasPrefix(pkgPath, "./") && !strings.HasPrefix(pkgPath, "../") {
+		return "", false
+	}
+	if strings.Contains(pkgPath, "...") {
+		return "", false
+	}
+	return canonicalDir(filepath.Join(wd, pkgPath)), true
+}
+
+// loadedPkgDir returns the directory of the provided loaded package. Returns "" if the directory cannot be determined.
+func loadedPkgDir(wd string, pkg *packages.Package) string {
+	if dir, ok := pkgDir(wd, pkg.ID); ok {
+		// packages that cannot be found are identified by the pattern that was used to load them
+		return dir
+	}
+	for _, files := range [][]string{pkg.GoFiles, pkg.OtherFiles, pkg.IgnoredFiles} {
+		if len(files) > 0 {
+			return canonicalDir(filepath.Dir(files[0]))
+		}
+	}
+	return ""
+}
+
+// canonicalDir returns the provided directory with any symbolic links resolved. Returns the cleaned directory if the
+// links cannot be resolved.
+func canonicalDir(dir string) string {
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return filepath.Clean(dir)
+	}
+	return resolved
+}
+
+// newIssueFromDiagnostic returns the issue for the provided diagnostic reported by the provided analyzer. The path of
+// the issue (and of its suggested fixes and related locations) is relative to wd.
+func newIssueFromDiagnostic(
+	analyzer *analysis.Analyzer,
+	fset *token.FileSet,
+	diagnostic analysis.Diagnostic,
+	wd string) okgo.Issue {
+	position := fset.Position(diagnostic.Pos)
+	issue := okgo.Issue{
+		Path:    relPath(position.Filename, wd),
+		Line:    position.Line,
+		Col:     position.Column,
+		Content: diagnostic.Message,
+		Rule:    analyzer.Name,
+	}
+	if diagnostic.End.IsValid() {
+		end := fset.Position(diagnostic.End)
+		issue.EndLine, issue.EndCol = end.Line, end.Column
+	}
+	for _, related := range diagnostic.Related {
+		start := fset.Position(related.Pos)
+		relatedLocation := okgo.RelatedLocation{
+			Path:    relPath(start.Filename, wd),
+			Line:    start.Line,
+			Col:     start.Column,
+			Message: related.Message,
+		}
+		if related.End.IsValid() {
+			end := fset.Position(related.End)
+			relatedLocation.EndLine, relatedLocation.EndCol = end.Line, end.Column
+		}
+		issue.Related = append(issue.Related, relatedLocation)
+	}
+	for _, fix := range diagnostic.SuggestedFixes {
+		suggestedFix := okgo.SuggestedFix{
+			Message: fix.Message,
+		}
+		for _, edit := range fix.TextEdits {
+			start := fset.Position(edit.Pos)
+			end := start
+			if edit.End.IsValid() {
+				end = fset.Position(edit.End)
+			}
+			suggestedFix.Edits = append(suggestedFix.Edits, okgo.TextEdit{
+				Path:    relPath(start.Filename, wd),
+				Offset:  start.Offset,
+				End:     end.Offset,
+				NewText: string(edit.NewText),
+			})
+		}
+		issue.SuggestedFixes = append(issue.SuggestedFixes, suggestedFix)
+	}
+	return issue
+}
+
+// relPath returns the provided path relative to wd. Returns the provided path if it is not absolute or if it cannot be
+// made relative.
+func relPath(path, wd string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+// issueSet is a set of issues. Packages are analyzed both with and without their tests, so the same issue can be
+// reported more than once.
+type issueSet struct {
+	seen   map[string]struct{}
+	issues []okgo.Issue
+}
+
+func newIssueSet() *issueSet {
+	return &issueSet{
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (s *issueSet) add(issue okgo.Issue) {
+	key, err := json.Marshal(issue)
+	if err != nil {
+		// Issue must support JSON-serialization
+		panic(errors.Wrapf(err, "failed to JSON-serialize issue %+v", issue))
+	}
+	if _, ok := s.seen[string(key)]; ok {
+		return
+	}
+	s.seen[string(key)] = struct{}{}
+	s.issues = append(s.issues, issue)
+}
+
+func (s *issueSet) sorted() []okgo.Issue {
+	sort.SliceStable(s.issues, func(i, j int) bool {
+		a, b := s.issues[i], s.issues[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Col < b.Col
+	})
+	return s.issues
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+)
+
+var badFuncAnalyzer = &analysis.Analyzer{
+	Name: "badfunc",
+	Doc:  "reports functions named bad",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		for _, file := range pass.Files {
+			for _, decl := range file.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok || funcDecl.Name.Name != "bad" {
+					continue
+				}
+				pass.Report(analysis.Diagnostic{
+					Pos:     funcDecl.Name.Pos(),
+					End:     funcDecl.Name.End(),
+					Message: "function should not be named bad",
+					Related: []analysis.RelatedInformation{{
+						Pos:     funcDecl.Pos(),
+						End:     funcDecl.End(),
+						Message: "declaration of bad",
+					}},
+					SuggestedFixes: []analysis.SuggestedFix{{
+						Message: "Rename to good",
+						TextEdits: []analysis.TextEdit{{
+							Pos:     funcDecl.Name.Pos(),
+							End:     funcDecl.Name.End(),
+							NewText: []byte("good"),
+						}},
+					}},
+				})
+			}
+		}
+		return nil, nil
+	},
+}
+
+func TestAnalysisChecker(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/foo\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "foo"), 0755))
+	src := "package foo\n\nfunc bad() {}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "foo", "foo.go"), []byte(src), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "foo", "foo_test.go"), []byte("package foo\n"), 0644))
+	t.Chdir(projectDir)
+
+	checker := NewAnalysisChecker(badFuncAnalyzer)
+	checkerType, err := checker.Type()
+	require.NoError(t, err)
+	assert.Equal(t, okgo.CheckerType("badfunc"), checkerType)
+
+	buf := &bytes.Buffer{}
+	checker.Check([]string{"./foo"}, projectDir, buf)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	// the issue is reported once even though the package is analyzed both with and without its tests
+	require.Len(t, lines, 1, buf.String())
+	nameOffset := strings.Index(src, "bad")
+	assert.Equal(t, okgo.Issue{
+		Path:    filepath.Join("foo", "foo.go"),
+		Line:    3,
+		Col:     6,
+		Content: "function should not be named bad",
+		EndLine: 3,
+		EndCol:  9,
+		Rule:    "badfunc",
+		SuggestedFixes: []okgo.SuggestedFix{{
+			Message: "Rename to good",
+			Edits: []okgo.TextEdit{{
+				Path:    filepath.Join("foo", "foo.go"),
+				Offset:  nameOffset,
+				End:     nameOffset + len("bad"),
+				NewText: "good",
+			}},
+		}},
+		Related: []okgo.RelatedLocation{{
+			Path:    filepath.Join("foo", "foo.go"),
+			Line:    3,
+			Col:     1,
+			EndLine: 3,
+			EndCol:  14,
+			Message: "declaration of bad",
+		}},
+	}, okgo.NewIssueFromJSON(lines[0]))
+}
+
+func TestAnalysisChecker_Type(t *testing.T) {
+	checkerType, err := NewAnalysisChecker(badFuncAnalyzer, &analysis.Analyzer{Name: "other"}).Type()
+	require.NoError(t, err)
+	assert.Equal(t, okgo.CheckerType("badfunc,other"), checkerType)
+}
+
+func TestAnalysisChecker_LoadErrors(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/foo\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "foo.go"), []byte("package foo\n\nvar x int = \"x\"\n"), 0644))
+	t.Chdir(projectDir)
+
+	buf := &bytes.Buffer{}
+	NewAnalysisChecker(badFuncAnalyzer).Check([]string{"."}, projectDir, buf)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1, buf.String())
+	issue := okgo.NewIssueFromJSON(lines[0])
+	assert.Equal(t, "foo.go", issue.Path)
+	assert.Equal(t, 3, issue.Line)
+	assert.Contains(t, issue.Content, "cannot use")
+}
+
+func TestAnalysisChecker_SharesPackagesWithinRun(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/foo\n\ngo 1.21\n"), 0644))
+	for _, pkg := range []string{"a", "b", "c"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, pkg), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, pkg, pkg+".go"), []byte("package "+pkg+"\n"), 0644))
+	}
+	t.Chdir(projectDir)
+
+	var (
+		lock sync.Mutex
+		seen = make(map[string]map[string]*types.Package)
+	)
+	newRecordingAnalyzer := func(name string) *analysis.Analyzer {
+		return &analysis.Analyzer{
+			Name: name,
+			Doc:  "records the packages that it analyzes",
+			Run: func(pass *analysis.Pass) (interface{}, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				if seen[name] == nil {
+					seen[name] = make(map[string]*types.Package)
+				}
+				seen[name][pass.Pkg.Path()] = pass.Pkg
+				return nil, nil
+			},
+		}
+	}
+	runChecks := func(ctx context.Context, checks map[string][]string) {
+		var wg sync.WaitGroup
+		for name, pkgPaths := range checks {
+			checker := NewAnalysisChecker(newRecordingAnalyzer(name)).(okgo.ContextChecker)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				buf := &bytes.Buffer{}
+				checker.CheckWithContext(ctx, pkgPaths, projectDir, buf)
+				assert.Empty(t, buf.String())
+			}()
+		}
+		wg.Wait()
+	}
+
+	runCtx := okgo.ContextWithRunCache(context.Background(), okgo.NewRunCache())
+	runChecks(runCtx, map[string][]string{
+		"first":  {"./a", "./b"},
+		"second": {"./b", "./c"},
+	})
+	require.Len(t, seen["first"], 2)
+	require.Len(t, seen["second"], 2)
+	// the checkers in the same run analyze the same type-checked package for the package that they both check
+	assert.True(t, seen["first"]["example.com/foo/b"] == seen["second"]["example.com/foo/b"])
+
+	// a checker in a different run loads the package again
+	runChecks(okgo.ContextWithRunCache(context.Background(), okgo.NewRunCache()), map[string][]string{
+		"third": {"./b"},
+	})
+	require.Len(t, seen["third"], 1)
+	assert.False(t, seen["first"]["example.com/foo/b"] == seen["third"]["example.com/foo/b"])
+
+	// a checker that is not run as part of a run does not share packages
+	runChecks(context.Background(), map[string][]string{
+		"fourth": {"./b"},
+	})
+	require.Len(t, seen["fourth"], 1)
+	assert.False(t, seen["first"]["example.com/foo/b"] == seen["fourth"]["example.com/foo/b"])
+}
+
+func TestPackageCache_Patterns(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/foo\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "a"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "a", "a.go"), []byte("package a\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "a", "a_test.go"), []byte("package a\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "empty"), 0755))
+
+	cache := newPackageCache()
+	pkgs, err := cache.load(projectDir, []string{"./a", "./empty", "./a"})
+	require.NoError(t, err)
+	var ids []string
+	for _, pkg := range pkgs {
+		ids = append(ids, pkg.ID)
+	}
+	// the package and its test variant are returned, the generated test main package is not and the error for the
+	// directory without Go files is returned as a package
+	assert.ElementsMatch(t, []string{"example.com/foo/a", "example.com/foo/a [example.com/foo/a.test]", "./empty"}, ids)
+
+	// patterns that are not directories are loaded every time
+	pkgs, err = cache.load(projectDir, []string{"./..."})
+	require.NoError(t, err)
+	assert.NotEmpty(t, pkgs)
+	assert.Len(t, cache.entries, 2)
+}
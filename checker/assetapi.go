@@ -35,6 +35,29 @@ func AssetRootCmd(creator Creator, upgradeConfigFn pluginapi.UpgradeConfigFn, sh
 	rootCmd.AddCommand(newTypeCmd(checkerType))
 	rootCmd.AddCommand(newPriorityCmd(creator.Priority()))
 	rootCmd.AddCommand(newMultiCPUCmd(creator.MultiCPU()))
+	var resources okgo.CheckerResources
+	if resourcesCreator, ok := creator.(ResourcesCreator); ok {
+		resources = resourcesCreator.Resources()
+	}
+	rootCmd.AddCommand(newResourcesCmd(resources))
+	var tags []string
+	if tagsCreator, ok := creator.(TagsCreator); ok {
+		tags = tagsCreator.Tags()
+	}
+	rootCmd.AddCommand(newTagsCmd(tags))
+	var configSchema json.RawMessage
+	if configSchemaCreator, ok := creator.(ConfigSchemaCreator); ok {
+		configSchema = configSchemaCreator.ConfigSchema()
+	}
+	rootCmd.AddCommand(newConfigSchemaCmd(configSchema))
+	rootCmd.AddCommand(newMetadataCmd(assetMetadata{
+		Type:         checkerType,
+		Priority:     creator.Priority(),
+		MultiCPU:     creator.MultiCPU(),
+		Resources:    resources,
+		Tags:         tags,
+		ConfigSchema: configSchema,
+	}))
 	rootCmd.AddCommand(newVerifyConfigCmd(creatorFn))
 	rootCmd.AddCommand(newCheckCmd(creatorFn))
 	rootCmd.AddCommand(newRunCheckCmdCmd(creatorFn))
@@ -94,6 +117,92 @@ func newMultiCPUCmd(cpu okgo.CheckerMultiCPU) *cobra.Command {
 	}
 }
 
+const resourcesCmdName = "resources"
+
+func newResourcesCmd(resources okgo.CheckerResources) *cobra.Command {
+	return &cobra.Command{
+		Use:   resourcesCmdName,
+		Short: "Print the resources used by the checker",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, err := json.Marshal(resources)
+			if err != nil {
+				return errors.Wrapf(err, "failed to marshal output as JSON")
+			}
+			cmd.Print(string(outputJSON))
+			return nil
+		},
+	}
+}
+
+const tagsCmdName = "tags"
+
+func newTagsCmd(tags []string) *cobra.Command {
+	return &cobra.Command{
+		Use:   tagsCmdName,
+		Short: "Print the tags of the checker",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if tags == nil {
+				tags = []string{}
+			}
+			outputJSON, err := json.Marshal(tags)
+			if err != nil {
+				return errors.Wrapf(err, "failed to marshal output as JSON")
+			}
+			cmd.Print(string(outputJSON))
+			return nil
+		},
+	}
+}
+
+const configSchemaCmdName = "config-schema"
+
+func newConfigSchemaCmd(schema json.RawMessage) *cobra.Command {
+	return &cobra.Command{
+		Use:   configSchemaCmdName,
+		Short: "Print the JSON Schema for the configuration of the checker",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if schema == nil {
+				// the empty schema accepts any configuration
+				schema = json.RawMessage("{}")
+			}
+			if !json.Valid(schema) {
+				return errors.Errorf("configuration schema is not valid JSON: %s", string(schema))
+			}
+			cmd.Print(string(schema))
+			return nil
+		},
+	}
+}
+
+const metadataCmdName = "metadata"
+
+// assetMetadata is the output of the "metadata" command, which provides the values of the type, priority, multicpu,
+// resources, tags and config-schema commands using a single invocation of the asset.
+type assetMetadata struct {
+	Type      okgo.CheckerType      `json:"type"`
+	Priority  okgo.CheckerPriority  `json:"priority"`
+	MultiCPU  okgo.CheckerMultiCPU  `json:"multiCPU"`
+	Resources okgo.CheckerResources `json:"resources"`
+	Tags      []string              `json:"tags,omitempty"`
+	// ConfigSchema is omitted if the checker does not provide a schema for its configuration.
+	ConfigSchema json.RawMessage `json:"configSchema,omitempty"`
+}
+
+func newMetadataCmd(metadata assetMetadata) *cobra.Command {
+	return &cobra.Command{
+		Use:   metadataCmdName,
+		Short: "Print the metadata of the checker",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, err := json.Marshal(metadata)
+			if err != nil {
+				return errors.Wrapf(err, "failed to marshal output as JSON")
+			}
+			cmd.Print(string(outputJSON))
+			return nil
+		},
+	}
+}
+
 const commonCmdConfigYMLFlagName = "config-yml"
 
 const (
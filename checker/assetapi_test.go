@@ -0,0 +1,92 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// minimalCreator is a Creator that does not implement any of the optional creator interfaces.
+type minimalCreator struct {
+	creator Creator
+}
+
+func (c *minimalCreator) Type() okgo.CheckerType {
+	return c.creator.Type()
+}
+
+func (c *minimalCreator) Priority() okgo.CheckerPriority {
+	return c.creator.Priority()
+}
+
+func (c *minimalCreator) MultiCPU() okgo.CheckerMultiCPU {
+	return c.creator.MultiCPU()
+}
+
+func (c *minimalCreator) Creator() CreatorFunction {
+	return c.creator.Creator()
+}
+
+func TestAssetRootCmd_Tags(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		creator Creator
+		want    string
+	}{
+		{
+			name:    "tags",
+			creator: NewCreatorWithParams("foo", 0, nil, CreatorParamTags("style", "slow")),
+			want:    `["style","slow"]`,
+		},
+		{
+			name:    "no tags",
+			creator: NewCreator("foo", 0, nil),
+			want:    `[]`,
+		},
+		{
+			name:    "creator that does not advertise tags",
+			creator: &minimalCreator{creator: NewCreatorWithParams("foo", 0, nil, CreatorParamTags("style"))},
+			want:    `[]`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			rootCmd := AssetRootCmd(tc.creator, nil, "")
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs([]string{tagsCmdName})
+			require.NoError(t, rootCmd.Execute())
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestAssetRootCmd_Metadata(t *testing.T) {
+	creator := NewCreatorWithParams("foo", 3, nil,
+		CreatorParamResources(okgo.CheckerResources{CPU: 2, MemoryMB: 512}),
+		CreatorParamTags("style", "slow"),
+		CreatorParamConfigSchema([]byte(`{"type": "object"}`)),
+	)
+	buf := &bytes.Buffer{}
+	rootCmd := AssetRootCmd(creator, nil, "")
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{metadataCmdName})
+	require.NoError(t, rootCmd.Execute())
+	assert.Equal(t, `{"type":"foo","priority":3,"multiCPU":false,"resources":{"cpu":2,"memoryMB":512},"tags":["style","slow"],"configSchema":{"type":"object"}}`, buf.String())
+}
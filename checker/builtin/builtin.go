@@ -0,0 +1,121 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package builtin provides checkers that are built into okgo and are configured entirely using the plugin
+// configuration rather than being provided by assets. A built-in check is only run if it is configured (either
+// directly or using an alias).
+package builtin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/palantir/okgo/checker"
+	"github.com/palantir/okgo/okgo"
+	"github.com/pkg/errors"
+)
+
+// Creators returns the creators and configuration upgraders for the built-in checkers.
+func Creators() ([]checker.Creator, []okgo.ConfigUpgrader) {
+	creators := []checker.Creator{
+		newCommandCreator(),
+		newForbiddenPatternsCreator(),
+		newImportPolicyCreator(),
+	}
+	var upgraders []okgo.ConfigUpgrader
+	for _, creator := range creators {
+		upgraders = append(upgraders, &configUpgrader{
+			typeName: creator.Type(),
+		})
+	}
+	return creators, upgraders
+}
+
+// configUpgrader is the configuration upgrader for a built-in checker. The configuration of built-in checkers has only
+// a single version, so the configuration is returned unmodified.
+type configUpgrader struct {
+	typeName okgo.CheckerType
+}
+
+func (u *configUpgrader) TypeName() okgo.CheckerType {
+	return u.typeName
+}
+
+func (u *configUpgrader) UpgradeConfig(config []byte) ([]byte, error) {
+	return config, nil
+}
+
+// validateRuleIDs returns an error if the provided rule IDs are not valid. Every rule must have a unique, non-empty ID.
+// There may be no rules, in which case the check does nothing.
+func validateRuleIDs(ids []string) error {
+	seen := make(map[string]struct{})
+	for i, id := range ids {
+		if id == "" {
+			return errors.Errorf("id must be specified for rule %d", i)
+		}
+		if _, ok := seen[id]; ok {
+			return errors.Errorf("multiple rules have id %q", id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// filesInPkgs returns the absolute paths of the regular files in the directories of the provided packages (which are
+// relative to wd) whose names are matched by the provided function.
+func filesInPkgs(pkgPaths []string, wd string, matchName func(name string) bool) ([]string, error) {
+	var files []string
+	for _, pkgPath := range pkgPaths {
+		pkgDir := filepath.Join(wd, pkgPath)
+		entries, err := os.ReadDir(pkgDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read directory %s", pkgPath)
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() || !matchName(entry.Name()) {
+				continue
+			}
+			files = append(files, filepath.Join(pkgDir, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// isGoFile returns true if the provided file name is the name of a Go file.
+func isGoFile(name string) bool {
+	return strings.HasSuffix(name, ".go")
+}
+
+// relPathOrAbs returns the provided absolute path relative to wd, or the absolute path if it cannot be made relative.
+func relPathOrAbs(path, wd string) string {
+	relPath, err := filepath.Rel(wd, path)
+	if err != nil {
+		return path
+	}
+	return relPath
+}
+
+// writeIssue writes the JSON representation of the provided issue to stdout on its own line.
+func writeIssue(issue okgo.Issue, stdout io.Writer) {
+	issueJSONBytes, err := json.Marshal(issue)
+	if err != nil {
+		okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to marshal issue %+v as JSON", issue), stdout)
+		return
+	}
+	_, _ = fmt.Fprintln(stdout, string(issueJSONBytes))
+}
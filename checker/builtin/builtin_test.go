@@ -0,0 +1,82 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProjectFiles writes the provided files (a map from slash-separated path to content) to a new temporary project
+// directory, changes the working directory to the project directory and returns its path.
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	projectDir := t.TempDir()
+	for file, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, filepath.Dir(file)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, file), []byte(content), 0644))
+	}
+	t.Chdir(projectDir)
+	return projectDir
+}
+
+// checkIssues runs the check of the provided checker on the provided packages and returns the issues that it reports.
+func checkIssues(checker okgo.Checker, pkgPaths []string, projectDir string) []okgo.Issue {
+	buf := &bytes.Buffer{}
+	checker.Check(pkgPaths, projectDir, buf)
+	var issues []okgo.Issue
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line != "" {
+			issues = append(issues, okgo.NewIssueFromJSON(line))
+		}
+	}
+	return issues
+}
+
+type invalidConfigCase struct {
+	cfg     string
+	wantErr string
+}
+
+// assertInvalidConfigs asserts that creating a checker using the provided function fails for the configuration of each
+// of the provided cases with an error that contains the expected message.
+func assertInvalidConfigs[T any](t *testing.T, newChecker func(cfgYML []byte) (T, error), cases []invalidConfigCase) {
+	for i, tc := range cases {
+		_, err := newChecker([]byte(tc.cfg))
+		require.Error(t, err, "Case %d", i)
+		assert.Contains(t, err.Error(), tc.wantErr, "Case %d", i)
+	}
+}
+
+func TestValidateRuleIDs(t *testing.T) {
+	for i, tc := range []struct {
+		ids     []string
+		wantErr string
+	}{
+		{[]string{"a", ""}, "id must be specified for rule 1"},
+		{[]string{"a", "b", "a"}, `multiple rules have id "a"`},
+	} {
+		assert.EqualError(t, validateRuleIDs(tc.ids), tc.wantErr, "Case %d", i)
+	}
+	assert.NoError(t, validateRuleIDs([]string{"a", "b"}))
+	// a check without rules does nothing
+	assert.NoError(t, validateRuleIDs(nil))
+}
@@ -0,0 +1,366 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/palantir/okgo/checker"
+	"github.com/palantir/okgo/checker/lineparser"
+	"github.com/palantir/okgo/okgo"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// CommandCheckerType is the type of the built-in checker that runs a command specified by configuration.
+const CommandCheckerType = okgo.CheckerType("command")
+
+const (
+	packagesArg = "{{packages}}"
+	filesArg    = "{{files}}"
+)
+
+// commandConfig is the configuration for the command checker.
+type commandConfig struct {
+	// Command is the command that is run. A relative path that contains a path separator is resolved relative to Dir.
+	Command string `yaml:"command"`
+	// Args are the arguments provided to the command. The argument "{{packages}}" is replaced by the paths of the
+	// packages that are checked and the argument "{{files}}" is replaced by the paths of the files in the directories
+	// of the packages that match FileNames.
+	Args []string `yaml:"args"`
+	// Dir is the working directory of the command relative to the project directory. If empty, the command is run in
+	// the working directory of okgo.
+	Dir string `yaml:"dir"`
+	// FileNames are regular expressions that are matched against the names of the files that are provided for
+	// "{{files}}". If empty, the Go files are provided.
+	FileNames []string `yaml:"file-names"`
+	// Parser is the name of the parser used to convert each line of the output of the command into an issue.
+	Parser string `yaml:"parser"`
+	// Pattern is a regular expression used to convert each line of the output of the command into an issue. The
+	// named groups "path", "line", "col", "message" and "rule" specify the corresponding fields of the issue.
+	Pattern string `yaml:"pattern"`
+}
+
+// lineParsers are the parsers that can be specified by name in the configuration of the command checker.
+var lineParsers = map[string]lineparser.Factory{
+	"default": lineparser.Default,
+	"json": lineparser.FromFunc(func(line, wd string) okgo.Issue {
+		return okgo.NewIssueFromJSON(line)
+	}),
+	"file-line":          lineparser.FileLine,
+	"go-build":           lineparser.GoBuild,
+	"go-vet-json":        lineparser.GoVetJSON,
+	"staticcheck-json":   lineparser.StaticcheckJSON,
+	"golangci-lint-json": lineparser.GolangCILintJSON,
+}
+
+const commandConfigSchema = `{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["command"],
+  "properties": {
+    "command": {
+      "description": "The command that is run.",
+      "type": "string"
+    },
+    "args": {
+      "description": "The arguments provided to the command. The argument \"{{packages}}\" is replaced by the paths of the packages and the argument \"{{files}}\" is replaced by the paths of the files in the directories of the packages that match \"file-names\".",
+      "type": "array",
+      "items": {"type": "string"}
+    },
+    "dir": {
+      "description": "The working directory of the command relative to the project directory.",
+      "type": "string"
+    },
+    "file-names": {
+      "description": "Regular expressions that are matched against the names of the files provided for \"{{files}}\". If empty, Go files are provided.",
+      "type": "array",
+      "items": {"type": "string"}
+    },
+    "parser": {
+      "description": "The name of the parser used to convert each line of output into an issue.",
+      "enum": ["default", "json", "file-line", "go-build", "go-vet-json", "staticcheck-json", "golangci-lint-json"]
+    },
+    "pattern": {
+      "description": "A regular expression used to convert each line of output into an issue using the named groups \"path\", \"line\", \"col\", \"message\" and \"rule\".",
+      "type": "string"
+    }
+  }
+}`
+
+func newCommandCreator() checker.Creator {
+	return checker.NewCreatorWithParams(CommandCheckerType, 0,
+		func(cfgYML []byte) (okgo.Checker, error) {
+			return newCommandChecker(cfgYML)
+		},
+		checker.CreatorParamConfigSchema([]byte(commandConfigSchema)),
+	)
+}
+
+type commandChecker struct {
+	cfg       commandConfig
+	fileNames []*regexp.Regexp
+	newParser lineparser.Factory
+}
+
+func newCommandChecker(cfgYML []byte) (*commandChecker, error) {
+	var cfg commandConfig
+	if err := yaml.UnmarshalStrict(cfgYML, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal configuration")
+	}
+	if cfg.Command == "" {
+		if !reflect.DeepEqual(cfg, commandConfig{}) {
+			return nil, errors.Errorf("command must be specified")
+		}
+		// an empty configuration disables the check so that it can be registered for projects that do not configure it
+		return &commandChecker{}, nil
+	}
+	c := &commandChecker{
+		cfg:       cfg,
+		newParser: lineParsers["default"],
+	}
+	for _, fileName := range cfg.FileNames {
+		fileNameRegexp, err := regexp.Compile(fileName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regular expression in file-names")
+		}
+		c.fileNames = append(c.fileNames, fileNameRegexp)
+	}
+	if len(c.fileNames) == 0 {
+		c.fileNames = []*regexp.Regexp{regexp.MustCompile(`\.go$`)}
+	}
+	switch {
+	case cfg.Parser != "" && cfg.Pattern != "":
+		return nil, errors.Errorf("at most one of parser and pattern may be specified")
+	case cfg.Parser != "":
+		newParser, ok := lineParsers[cfg.Parser]
+		if !ok {
+			return nil, errors.Errorf("unknown parser %q", cfg.Parser)
+		}
+		c.newParser = newParser
+	case cfg.Pattern != "":
+		parser, err := newPatternLineParser(cfg.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		c.newParser = lineparser.FromFunc(parser)
+	}
+	return c, nil
+}
+
+// newPatternLineParser returns a line parser that creates issues using the named groups of the provided regular
+// expression. Lines that do not match the expression are reported as issues whose content is the line.
+func newPatternLineParser(pattern string) (func(line, wd string) okgo.Issue, error) {
+	patternRegexp, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid regular expression in pattern")
+	}
+	if patternRegexp.SubexpIndex("message") == -1 {
+		return nil, errors.Errorf("pattern must contain a group named \"message\"")
+	}
+	return func(line, wd string) okgo.Issue {
+		matches := patternRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			return okgo.Issue{
+				Content: line,
+			}
+		}
+		group := func(name string) string {
+			if idx := patternRegexp.SubexpIndex(name); idx != -1 {
+				return matches[idx]
+			}
+			return ""
+		}
+		issue := okgo.Issue{
+			Path:    group("path"),
+			Content: group("message"),
+			Rule:    group("rule"),
+		}
+		if filepath.IsAbs(issue.Path) {
+			if relPath, err := filepath.Rel(wd, issue.Path); err == nil {
+				issue.Path = relPath
+			}
+		}
+		issue.Line, _ = strconv.Atoi(group("line"))
+		issue.Col, _ = strconv.Atoi(group("col"))
+		return issue
+	}, nil
+}
+
+func (c *commandChecker) Type() (okgo.CheckerType, error) {
+	return CommandCheckerType, nil
+}
+
+func (c *commandChecker) Priority() (okgo.CheckerPriority, error) {
+	return 0, nil
+}
+
+func (c *commandChecker) MultiCPU() (okgo.CheckerMultiCPU, error) {
+	return false, nil
+}
+
+func (c *commandChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	c.CheckWithContext(context.Background(), pkgPaths, projectDir, stdout)
+}
+
+func (c *commandChecker) CheckWithContext(ctx context.Context, pkgPaths []string, projectDir string, stdout io.Writer) {
+	if c.cfg.Command == "" {
+		return
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to determine working directory"), stdout)
+		return
+	}
+	cmdDir := wd
+	if c.cfg.Dir != "" {
+		cmdDir = filepath.Join(projectDir, c.cfg.Dir)
+		if !filepath.IsAbs(cmdDir) {
+			cmdDir = filepath.Join(wd, cmdDir)
+		}
+	}
+	args, err := c.expandArgs(pkgPaths, wd, cmdDir)
+	if err != nil {
+		okgo.WriteErrorAsIssue(err, stdout)
+		return
+	}
+	if args == nil {
+		// "{{files}}" did not match any files
+		return
+	}
+
+	cmd := checker.CommandContext(ctx, c.cfg.Command, args...)
+	cmd.Dir = cmdDir
+	parser := c.newParser(cmdDir)
+	if cmdDir != wd {
+		parser = &cmdDirLineParser{
+			parser: parser,
+			wd:     wd,
+			cmdDir: cmdDir,
+		}
+	}
+	checker.RunCommandAndStreamWithParser(cmd, parser, stdout)
+}
+
+// cmdDirLineParser is a LineParser for the output of a command that is run in a directory other than the working
+// directory. Paths in the output are relative to the directory of the command, while issue paths are relative to the
+// working directory, so the paths of the issues returned by the underlying parser are converted.
+type cmdDirLineParser struct {
+	parser lineparser.LineParser
+	wd     string
+	cmdDir string
+}
+
+func (p *cmdDirLineParser) ParseLine(line string) []okgo.Issue {
+	return p.relPathsToWd(p.parser.ParseLine(line))
+}
+
+func (p *cmdDirLineParser) Flush() []okgo.Issue {
+	return p.relPathsToWd(p.parser.Flush())
+}
+
+func (p *cmdDirLineParser) relPathsToWd(issues []okgo.Issue) []okgo.Issue {
+	for i := range issues {
+		issues[i].Path = cmdRelPathToWd(issues[i].Path, p.wd, p.cmdDir)
+		for j := range issues[i].Related {
+			issues[i].Related[j].Path = cmdRelPathToWd(issues[i].Related[j].Path, p.wd, p.cmdDir)
+		}
+	}
+	return issues
+}
+
+// cmdRelPathToWd returns the provided path, which is relative to cmdDir, relative to wd. Returns the provided path if
+// it is empty or absolute or if it cannot be made relative.
+func cmdRelPathToWd(path, wd, cmdDir string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	relPath, err := filepath.Rel(wd, filepath.Join(cmdDir, path))
+	if err != nil {
+		return path
+	}
+	return relPath
+}
+
+// expandArgs returns the arguments for the command with "{{packages}}" and "{{files}}" replaced by the provided
+// packages and the files in their directories. The paths are relative to cmdDir. Returns nil if the arguments contain
+// "{{files}}" and no files match.
+func (c *commandChecker) expandArgs(pkgPaths []string, wd, cmdDir string) ([]string, error) {
+	args := []string{}
+	for _, arg := range c.cfg.Args {
+		switch arg {
+		case packagesArg:
+			for _, pkgPath := range pkgPaths {
+				args = append(args, relArgPath(filepath.Join(wd, pkgPath), cmdDir))
+			}
+		case filesArg:
+			files, err := filesInPkgs(pkgPaths, wd, c.matchesFileName)
+			if err != nil {
+				return nil, err
+			}
+			if len(files) == 0 {
+				return nil, nil
+			}
+			for _, file := range files {
+				args = append(args, relArgPath(file, cmdDir))
+			}
+		default:
+			args = append(args, arg)
+		}
+	}
+	return args, nil
+}
+
+func (c *commandChecker) matchesFileName(name string) bool {
+	for _, fileName := range c.fileNames {
+		if fileName.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// relArgPath returns the provided absolute path relative to dir. Paths in dir or its subdirectories are prefixed
+// with "./" so that they are not interpreted as import paths.
+func relArgPath(path, dir string) string {
+	relPath, err := filepath.Rel(dir, path)
+	if err != nil {
+		return path
+	}
+	if relPath == "." || strings.HasPrefix(relPath, "..") {
+		return relPath
+	}
+	return "." + string(filepath.Separator) + relPath
+}
+
+func (c *commandChecker) RunCheckCmd(args []string, stdout io.Writer) {
+	cmd := exec.Command(c.cfg.Command, args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stdout
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			_, _ = fmt.Fprintf(stdout, "command %v failed with error %v\n", cmd.Args, err)
+		}
+	}
+}
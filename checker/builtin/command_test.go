@@ -0,0 +1,130 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandChecker(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"foo/foo.go":  "",
+		"foo/run.sh":  "",
+		"bar/bar.go":  "",
+		"tools/.keep": "",
+	})
+
+	for i, tc := range []struct {
+		name string
+		cfg  string
+		pkgs []string
+		want []okgo.Issue
+	}{
+		{
+			name: "packages are provided and output is parsed using the default parser",
+			cfg: `command: sh
+args: ["-c", 'for p in "$@"; do echo "$p/x.go:1:2: found"; done', "sh", "{{packages}}"]
+`,
+			pkgs: []string{"./foo", "./bar"},
+			want: []okgo.Issue{
+				{Path: "./foo/x.go", Line: 1, Col: 2, Content: "found"},
+				{Path: "./bar/x.go", Line: 1, Col: 2, Content: "found"},
+			},
+		},
+		{
+			name: "files matching file-names are provided",
+			cfg: `command: sh
+args: ["-c", 'for f in "$@"; do echo "$f:3: bad script"; done', "sh", "{{files}}"]
+file-names: ['\.sh$']
+pattern: '^(?P<path>[^:]+):(?P<line>\d+): (?P<message>.+)$'
+`,
+			pkgs: []string{"./foo", "./bar"},
+			want: []okgo.Issue{
+				{Path: "./foo/run.sh", Line: 3, Content: "bad script"},
+			},
+		},
+		{
+			name: "command is not run if no files match",
+			cfg: `command: sh
+args: ["-c", "echo ran", "sh", "{{files}}"]
+file-names: ['\.sh$']
+`,
+			pkgs: []string{"./bar"},
+		},
+		{
+			name: "paths are relative to the directory of the command",
+			cfg: `command: sh
+args: ["-c", 'echo "$1/bar.go:4:1: [rule-1] in dir"', "sh", "{{packages}}"]
+dir: tools
+pattern: '^(?P<path>[^:]+):(?P<line>\d+):(?P<col>\d+): \[(?P<rule>[^]]+)\] (?P<message>.+)$'
+`,
+			pkgs: []string{"./bar"},
+			want: []okgo.Issue{
+				{Path: "bar/bar.go", Line: 4, Col: 1, Content: "in dir", Rule: "rule-1"},
+			},
+		},
+		{
+			name: "named parsers can report multiple issues for a line",
+			cfg: `command: sh
+args: ["-c", 'echo "{\"Issues\":[{\"FromLinter\":\"a\",\"Text\":\"first\",\"Pos\":{\"Filename\":\"foo/foo.go\",\"Line\":1,\"Column\":1}},{\"FromLinter\":\"b\",\"Text\":\"second\",\"Pos\":{\"Filename\":\"foo/foo.go\",\"Line\":2,\"Column\":1}}]}"']
+parser: golangci-lint-json
+`,
+			pkgs: []string{"./foo"},
+			want: []okgo.Issue{
+				{Path: "foo/foo.go", Line: 1, Col: 1, Content: "first", Rule: "a"},
+				{Path: "foo/foo.go", Line: 2, Col: 1, Content: "second", Rule: "b"},
+			},
+		},
+		{
+			name: "output is parsed as JSON issues",
+			cfg: `command: sh
+args: ["-c", 'echo "{\"path\":\"foo/foo.go\",\"line\":7,\"content\":\"json\"}"']
+parser: json
+`,
+			pkgs: []string{"./foo"},
+			want: []okgo.Issue{
+				{Path: "foo/foo.go", Line: 7, Content: "json"},
+			},
+		},
+	} {
+		checker, err := newCommandChecker([]byte(tc.cfg))
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, checkIssues(checker, tc.pkgs, projectDir), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestCommandChecker_NoCommand(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"foo/foo.go": "package foo\n",
+	})
+	checker, err := newCommandChecker(nil)
+	require.NoError(t, err)
+	assert.Empty(t, checkIssues(checker, []string{"./foo"}, projectDir))
+}
+
+func TestCommandChecker_InvalidConfig(t *testing.T) {
+	assertInvalidConfigs(t, newCommandChecker, []invalidConfigCase{
+		{"args: [foo]\n", "command must be specified"},
+		{"command: foo\nunknown: true\n", "failed to unmarshal configuration"},
+		{"command: foo\nparser: unknown\n", `unknown parser "unknown"`},
+		{"command: foo\nparser: json\npattern: '(?P<message>.*)'\n", "at most one of parser and pattern may be specified"},
+		{"command: foo\npattern: '(.*)'\n", `pattern must contain a group named "message"`},
+		{"command: foo\nfile-names: ['[']\n", "invalid regular expression in file-names"},
+	})
+}
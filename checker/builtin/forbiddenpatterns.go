@@ -0,0 +1,332 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/palantir/okgo/checker"
+	"github.com/palantir/okgo/okgo"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ForbiddenPatternsCheckerType is the type of the built-in checker that reports matches of forbidden regular
+// expressions in Go source files.
+const ForbiddenPatternsCheckerType = okgo.CheckerType("forbiddenpatterns")
+
+type forbiddenPatternsConfig struct {
+	Rules []forbiddenPatternRuleConfig `yaml:"rules"`
+}
+
+type forbiddenPatternRuleConfig struct {
+	// ID identifies the rule. It is reported as the rule of the issues for the rule.
+	ID string `yaml:"id"`
+	// Pattern is the forbidden regular expression. It is matched against the entire content of each file, so "(?m)"
+	// can be used to match "^" and "$" at line boundaries.
+	Pattern string `yaml:"pattern"`
+	// Message is the content of the issues for the rule.
+	Message string `yaml:"message"`
+	// Paths are regular expressions matched against the paths of files relative to the project directory. If
+	// non-empty, the rule only applies to files whose path matches at least one of the expressions.
+	Paths []string `yaml:"paths"`
+	// Exclude are regular expressions matched against the paths of files relative to the project directory. The rule
+	// does not apply to files whose path matches any of the expressions.
+	Exclude []string `yaml:"exclude"`
+}
+
+const forbiddenPatternsConfigSchema = `{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "rules": {
+      "description": "The forbidden patterns.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["id", "pattern"],
+        "properties": {
+          "id": {
+            "description": "The identifier of the rule.",
+            "type": "string"
+          },
+          "pattern": {
+            "description": "The forbidden regular expression.",
+            "type": "string"
+          },
+          "message": {
+            "description": "The message reported for each match.",
+            "type": "string"
+          },
+          "paths": {
+            "description": "Regular expressions matched against the paths of files relative to the project directory. If specified, the rule only applies to matching files.",
+            "type": "array",
+            "items": {"type": "string"}
+          },
+          "exclude": {
+            "description": "Regular expressions matched against the paths of files relative to the project directory. The rule does not apply to matching files.",
+            "type": "array",
+            "items": {"type": "string"}
+          }
+        }
+      }
+    }
+  }
+}`
+
+func newForbiddenPatternsCreator() checker.Creator {
+	return checker.NewCreatorWithParams(ForbiddenPatternsCheckerType, 0,
+		func(cfgYML []byte) (okgo.Checker, error) {
+			return newForbiddenPatternsChecker(cfgYML)
+		},
+		checker.CreatorParamConfigSchema([]byte(forbiddenPatternsConfigSchema)),
+	)
+}
+
+type forbiddenPatternsChecker struct {
+	rules []forbiddenPatternRule
+}
+
+type forbiddenPatternRule struct {
+	id      string
+	pattern *regexp.Regexp
+	message string
+	paths   []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func newForbiddenPatternsChecker(cfgYML []byte) (*forbiddenPatternsChecker, error) {
+	var cfg forbiddenPatternsConfig
+	if err := yaml.UnmarshalStrict(cfgYML, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal configuration")
+	}
+	var ids []string
+	for _, ruleCfg := range cfg.Rules {
+		ids = append(ids, ruleCfg.ID)
+	}
+	if err := validateRuleIDs(ids); err != nil {
+		return nil, err
+	}
+	c := &forbiddenPatternsChecker{}
+	for _, ruleCfg := range cfg.Rules {
+		rule, err := ruleCfg.toRule()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rule %q", ruleCfg.ID)
+		}
+		c.rules = append(c.rules, rule)
+	}
+	return c, nil
+}
+
+func (cfg forbiddenPatternRuleConfig) toRule() (forbiddenPatternRule, error) {
+	if cfg.Pattern == "" {
+		return forbiddenPatternRule{}, errors.Errorf("pattern must be specified")
+	}
+	pattern, err := regexp.Compile(cfg.Pattern)
+	if err != nil {
+		return forbiddenPatternRule{}, errors.Wrapf(err, "invalid regular expression in pattern")
+	}
+	if pattern.MatchString("") {
+		// a pattern that matches the empty string would report an issue at every offset of every file
+		return forbiddenPatternRule{}, errors.Errorf("pattern must not match the empty string")
+	}
+	paths, err := compileRegexps(cfg.Paths)
+	if err != nil {
+		return forbiddenPatternRule{}, errors.Wrapf(err, "invalid regular expression in paths")
+	}
+	exclude, err := compileRegexps(cfg.Exclude)
+	if err != nil {
+		return forbiddenPatternRule{}, errors.Wrapf(err, "invalid regular expression in exclude")
+	}
+	message := cfg.Message
+	if message == "" {
+		message = fmt.Sprintf("matches forbidden pattern %q", cfg.Pattern)
+	}
+	return forbiddenPatternRule{
+		id:      cfg.ID,
+		pattern: pattern,
+		message: message,
+		paths:   paths,
+		exclude: exclude,
+	}, nil
+}
+
+func compileRegexps(exprs []string) ([]*regexp.Regexp, error) {
+	var out []*regexp.Regexp
+	for _, expr := range exprs {
+		compiled, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, compiled)
+	}
+	return out, nil
+}
+
+// appliesTo returns true if the rule applies to the file with the provided slash-separated path relative to the
+// project directory.
+func (r forbiddenPatternRule) appliesTo(relPath string) bool {
+	if len(r.paths) > 0 && !matchesAny(r.paths, relPath) {
+		return false
+	}
+	return !matchesAny(r.exclude, relPath)
+}
+
+func matchesAny(exprs []*regexp.Regexp, s string) bool {
+	for _, expr := range exprs {
+		if expr.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *forbiddenPatternsChecker) Type() (okgo.CheckerType, error) {
+	return ForbiddenPatternsCheckerType, nil
+}
+
+func (c *forbiddenPatternsChecker) Priority() (okgo.CheckerPriority, error) {
+	return 0, nil
+}
+
+func (c *forbiddenPatternsChecker) MultiCPU() (okgo.CheckerMultiCPU, error) {
+	return false, nil
+}
+
+func (c *forbiddenPatternsChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	c.CheckWithContext(context.Background(), pkgPaths, projectDir, stdout)
+}
+
+func (c *forbiddenPatternsChecker) CheckWithContext(ctx context.Context, pkgPaths []string, projectDir string, stdout io.Writer) {
+	if len(c.rules) == 0 {
+		return
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to determine working directory"), stdout)
+		return
+	}
+	absProjectDir := projectDir
+	if !filepath.IsAbs(absProjectDir) {
+		absProjectDir = filepath.Join(wd, projectDir)
+	}
+	files, err := filesInPkgs(pkgPaths, wd, isGoFile)
+	if err != nil {
+		okgo.WriteErrorAsIssue(err, stdout)
+		return
+	}
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		issues, err := c.checkFile(file, wd, absProjectDir)
+		if err != nil {
+			okgo.WriteErrorAsIssue(err, stdout)
+			continue
+		}
+		for _, issue := range issues {
+			writeIssue(issue, stdout)
+		}
+	}
+}
+
+// checkFile returns the issues for the matches of the rules in the provided file in order of their position.
+func (c *forbiddenPatternsChecker) checkFile(file, wd, projectDir string) ([]okgo.Issue, error) {
+	projectRelPath, err := filepath.Rel(projectDir, file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to determine path of %s relative to project directory", file)
+	}
+	projectRelPath = filepath.ToSlash(projectRelPath)
+	var rules []forbiddenPatternRule
+	for _, rule := range c.rules {
+		if rule.appliesTo(projectRelPath) {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", file)
+	}
+	issuePath := relPathOrAbs(file, wd)
+	lineStarts := newLineIndex(content)
+
+	type match struct {
+		offset int
+		issue  okgo.Issue
+	}
+	var matches []match
+	for _, rule := range rules {
+		for _, loc := range rule.pattern.FindAllIndex(content, -1) {
+			line, col := lineStarts.position(loc[0])
+			endLine, endCol := lineStarts.position(loc[1])
+			matches = append(matches, match{
+				offset: loc[0],
+				issue: okgo.Issue{
+					Path:    issuePath,
+					Line:    line,
+					Col:     col,
+					EndLine: endLine,
+					EndCol:  endCol,
+					Content: rule.message,
+					Rule:    rule.id,
+				},
+			})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].offset < matches[j].offset
+	})
+	issues := make([]okgo.Issue, len(matches))
+	for i, m := range matches {
+		issues[i] = m.issue
+	}
+	return issues, nil
+}
+
+func (c *forbiddenPatternsChecker) RunCheckCmd(args []string, stdout io.Writer) {
+	c.Check(args, ".", stdout)
+}
+
+// lineIndex is the byte offsets of the starts of the lines of a file.
+type lineIndex []int
+
+func newLineIndex(content []byte) lineIndex {
+	idx := lineIndex{0}
+	for i, b := range content {
+		if b == '\n' {
+			idx = append(idx, i+1)
+		}
+	}
+	return idx
+}
+
+// position returns the 1-based line and column (in bytes) of the provided byte offset.
+func (idx lineIndex) position(offset int) (int, int) {
+	line := sort.Search(len(idx), func(i int) bool {
+		return idx[i] > offset
+	})
+	return line, offset - idx[line-1] + 1
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForbiddenPatternsChecker(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"foo/foo.go": `package foo
+
+import "fmt"
+
+// TODO: fix this
+func Foo() { fmt.Println("foo") }
+`,
+		"foo/foo_test.go": `package foo
+
+import "time"
+
+func TestFoo() {
+	time.Sleep(time.Second); time.Sleep(time.Second)
+}
+`,
+		"foo/gen/gen.go": `package gen
+
+import "fmt"
+
+func Gen() { fmt.Println("gen") }
+`,
+		"foo/notes.txt": "TODO: not Go\n",
+	})
+
+	checker, err := newForbiddenPatternsChecker([]byte(`rules:
+  - id: no-sleep-in-tests
+    pattern: 'time\.Sleep\('
+    message: do not sleep in tests
+    paths: ['_test\.go$']
+  - id: no-print
+    pattern: 'fmt\.Print'
+    message: do not print in libraries
+    exclude: ['^foo/gen/']
+  - id: todo-ticket
+    pattern: '(?m)TODO(:|$)'
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []okgo.Issue{
+		{Path: "foo/foo.go", Line: 5, Col: 4, EndLine: 5, EndCol: 9, Content: `matches forbidden pattern "(?m)TODO(:|$)"`, Rule: "todo-ticket"},
+		{Path: "foo/foo.go", Line: 6, Col: 14, EndLine: 6, EndCol: 23, Content: "do not print in libraries", Rule: "no-print"},
+		{Path: "foo/foo_test.go", Line: 6, Col: 2, EndLine: 6, EndCol: 13, Content: "do not sleep in tests", Rule: "no-sleep-in-tests"},
+		{Path: "foo/foo_test.go", Line: 6, Col: 27, EndLine: 6, EndCol: 38, Content: "do not sleep in tests", Rule: "no-sleep-in-tests"},
+	}, checkIssues(checker, []string{"./foo", "./foo/gen"}, projectDir))
+}
+
+func TestForbiddenPatternsChecker_NoRules(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"foo/foo.go": "package foo\n",
+	})
+	// a project that does not configure the check can still run all of the registered checks
+	checker, err := newForbiddenPatternsChecker(nil)
+	require.NoError(t, err)
+	assert.Empty(t, checkIssues(checker, []string{"./foo"}, projectDir))
+}
+
+func TestForbiddenPatternsChecker_InvalidConfig(t *testing.T) {
+	assertInvalidConfigs(t, newForbiddenPatternsChecker, []invalidConfigCase{
+		{"rules:\n  - id: a\n", `invalid rule "a": pattern must be specified`},
+		{"rules:\n  - id: a\n    pattern: '('\n", `invalid rule "a": invalid regular expression in pattern`},
+		{"rules:\n  - id: a\n    pattern: 'a*'\n", `invalid rule "a": pattern must not match the empty string`},
+		{"rules:\n  - id: a\n    pattern: foo\n    exclude: ['(']\n", `invalid rule "a": invalid regular expression in exclude`},
+	})
+}
@@ -0,0 +1,333 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/palantir/okgo/checker"
+	"github.com/palantir/okgo/okgo"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v2"
+)
+
+// ImportPolicyCheckerType is the type of the built-in checker that enforces rules for the imports of packages.
+const ImportPolicyCheckerType = okgo.CheckerType("importpolicy")
+
+type importPolicyConfig struct {
+	Rules []importPolicyRuleConfig `yaml:"rules"`
+}
+
+type importPolicyRuleConfig struct {
+	// ID identifies the rule. It is reported as the rule of the issues for the rule.
+	ID string `yaml:"id"`
+	// Dirs are the directories relative to the project directory that the rule applies to. A rule that applies to a
+	// directory also applies to its subdirectories. If empty, the rule applies to all packages.
+	Dirs []string `yaml:"dirs"`
+	// Banned are the import path patterns that the packages may not import.
+	Banned []string `yaml:"banned"`
+	// Allowed are the import path patterns that the packages may import. If non-empty, packages may only import the
+	// standard library and the packages that match these patterns.
+	Allowed []string `yaml:"allowed"`
+	// Message is the content of the issues for the rule.
+	Message string `yaml:"message"`
+}
+
+const importPolicyConfigSchema = `{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "rules": {
+      "description": "The import rules.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["id"],
+        "properties": {
+          "id": {
+            "description": "The identifier of the rule.",
+            "type": "string"
+          },
+          "dirs": {
+            "description": "The directories (relative to the project directory) that the rule applies to, including their subdirectories. If not specified, the rule applies to all packages.",
+            "type": "array",
+            "items": {"type": "string"}
+          },
+          "banned": {
+            "description": "Import path patterns that may not be imported. A pattern ending in \"/...\" matches the path and all paths under it, and a pattern starting with \"./\" is relative to the module path declared in the go.mod file of the project directory.",
+            "type": "array",
+            "items": {"type": "string"}
+          },
+          "allowed": {
+            "description": "Import path patterns that may be imported. If specified, only the standard library and matching packages may be imported.",
+            "type": "array",
+            "items": {"type": "string"}
+          },
+          "message": {
+            "description": "The message reported for each import that violates the rule.",
+            "type": "string"
+          }
+        }
+      }
+    }
+  }
+}`
+
+func newImportPolicyCreator() checker.Creator {
+	return checker.NewCreatorWithParams(ImportPolicyCheckerType, 0,
+		func(cfgYML []byte) (okgo.Checker, error) {
+			return newImportPolicyChecker(cfgYML)
+		},
+		checker.CreatorParamConfigSchema([]byte(importPolicyConfigSchema)),
+	)
+}
+
+type importPolicyChecker struct {
+	rules []importPolicyRuleConfig
+}
+
+func newImportPolicyChecker(cfgYML []byte) (*importPolicyChecker, error) {
+	var cfg importPolicyConfig
+	if err := yaml.UnmarshalStrict(cfgYML, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal configuration")
+	}
+	var ids []string
+	for _, rule := range cfg.Rules {
+		ids = append(ids, rule.ID)
+	}
+	if err := validateRuleIDs(ids); err != nil {
+		return nil, err
+	}
+	for _, rule := range cfg.Rules {
+		if len(rule.Banned) == 0 && len(rule.Allowed) == 0 {
+			return nil, errors.Errorf("invalid rule %q: at least one of banned and allowed must be specified", rule.ID)
+		}
+	}
+	return &importPolicyChecker{
+		rules: cfg.Rules,
+	}, nil
+}
+
+// appliesTo returns true if the rule applies to the package in the provided slash-separated directory relative to the
+// project directory.
+func (r importPolicyRuleConfig) appliesTo(pkgDir string) bool {
+	if len(r.Dirs) == 0 {
+		return true
+	}
+	for _, dir := range r.Dirs {
+		dir = path.Clean(dir)
+		if dir == "." || pkgDir == dir || strings.HasPrefix(pkgDir, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// violation returns the reason that the provided import path violates the rule. Returns an empty string if the import
+// is permitted.
+func (r importPolicyRuleConfig) violation(importPath, modulePath string) string {
+	if pattern, ok := matchImportPatterns(r.Banned, importPath, modulePath); ok {
+		return fmt.Sprintf("import %q is banned (matches %q)", importPath, pattern)
+	}
+	if len(r.Allowed) == 0 || isStandardLibrary(importPath, modulePath) {
+		return ""
+	}
+	if _, ok := matchImportPatterns(r.Allowed, importPath, modulePath); !ok {
+		return fmt.Sprintf("import %q is not allowed (allowed: %v)", importPath, r.Allowed)
+	}
+	return ""
+}
+
+// modulePattern returns the first of the banned and allowed patterns of the rule that is relative to the module of the
+// project.
+func (r importPolicyRuleConfig) modulePattern() (string, bool) {
+	for _, pattern := range append(append([]string(nil), r.Banned...), r.Allowed...) {
+		if strings.HasPrefix(pattern, "./") {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// matchImportPatterns returns the first of the provided patterns that matches the import path.
+func matchImportPatterns(patterns []string, importPath, modulePath string) (string, bool) {
+	for _, pattern := range patterns {
+		if matchImportPattern(pattern, importPath, modulePath) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// matchImportPattern returns true if the provided import path matches the pattern. A pattern that ends in "/..."
+// matches the path before the suffix and all of the paths under it, and a pattern that starts with "./" is relative to
+// the provided module path.
+func matchImportPattern(pattern, importPath, modulePath string) bool {
+	if strings.HasPrefix(pattern, "./") {
+		if modulePath == "" {
+			return false
+		}
+		pattern = path.Join(modulePath, pattern)
+	}
+	if prefix := strings.TrimSuffix(pattern, "/..."); prefix != pattern {
+		return importPath == prefix || strings.HasPrefix(importPath, prefix+"/")
+	}
+	return importPath == pattern
+}
+
+// isStandardLibrary returns true if the provided import path is the path of a package in the standard library (the
+// first element of the path does not contain a dot). Packages in the module with the provided path are never part of
+// the standard library, even if the module path does not contain a dot.
+func isStandardLibrary(importPath, modulePath string) bool {
+	if modulePath != "" && (importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")) {
+		return false
+	}
+	firstElem := strings.SplitN(importPath, "/", 2)[0]
+	return !strings.Contains(firstElem, ".")
+}
+
+func (c *importPolicyChecker) Type() (okgo.CheckerType, error) {
+	return ImportPolicyCheckerType, nil
+}
+
+func (c *importPolicyChecker) Priority() (okgo.CheckerPriority, error) {
+	return 0, nil
+}
+
+func (c *importPolicyChecker) MultiCPU() (okgo.CheckerMultiCPU, error) {
+	return false, nil
+}
+
+func (c *importPolicyChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	c.CheckWithContext(context.Background(), pkgPaths, projectDir, stdout)
+}
+
+func (c *importPolicyChecker) CheckWithContext(ctx context.Context, pkgPaths []string, projectDir string, stdout io.Writer) {
+	if len(c.rules) == 0 {
+		return
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to determine working directory"), stdout)
+		return
+	}
+	absProjectDir := projectDir
+	if !filepath.IsAbs(absProjectDir) {
+		absProjectDir = filepath.Join(wd, projectDir)
+	}
+	modulePath, err := readModulePath(absProjectDir)
+	if err != nil {
+		okgo.WriteErrorAsIssue(err, stdout)
+		return
+	}
+	if modulePath == "" {
+		for _, rule := range c.rules {
+			if pattern, ok := rule.modulePattern(); ok {
+				okgo.WriteErrorAsIssue(errors.Errorf("rule %q uses pattern %q, which is relative to the module of the project, but no module path is declared in %s", rule.ID, pattern, filepath.Join(projectDir, "go.mod")), stdout)
+				return
+			}
+		}
+	}
+
+	for _, pkgPath := range pkgPaths {
+		if ctx.Err() != nil {
+			return
+		}
+		pkgDir, err := filepath.Rel(absProjectDir, filepath.Join(wd, pkgPath))
+		if err != nil {
+			okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to determine path of %s relative to project directory", pkgPath), stdout)
+			continue
+		}
+		var rules []importPolicyRuleConfig
+		for _, rule := range c.rules {
+			if rule.appliesTo(filepath.ToSlash(pkgDir)) {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		files, err := filesInPkgs([]string{pkgPath}, wd, isGoFile)
+		if err != nil {
+			okgo.WriteErrorAsIssue(err, stdout)
+			continue
+		}
+		for _, file := range files {
+			checkFileImports(file, wd, modulePath, rules, stdout)
+		}
+	}
+}
+
+// checkFileImports writes an issue for each import in the provided file that violates one of the provided rules.
+func checkFileImports(file, wd, modulePath string, rules []importPolicyRuleConfig, stdout io.Writer) {
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly)
+	if err != nil {
+		okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to parse %s", relPathOrAbs(file, wd)), stdout)
+		return
+	}
+	for _, importSpec := range parsed.Imports {
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			continue
+		}
+		for _, rule := range rules {
+			reason := rule.violation(importPath, modulePath)
+			if reason == "" {
+				continue
+			}
+			content := reason
+			if rule.Message != "" {
+				content = rule.Message + ": " + reason
+			}
+			position := fset.Position(importSpec.Path.Pos())
+			writeIssue(okgo.Issue{
+				Path:    relPathOrAbs(file, wd),
+				Line:    position.Line,
+				Col:     position.Column,
+				Content: content,
+				Rule:    rule.ID,
+			}, stdout)
+		}
+	}
+}
+
+// readModulePath returns the module path declared in the go.mod file in the provided directory. Returns an empty
+// string if the file does not exist or does not declare a module path.
+func readModulePath(dir string) (string, error) {
+	goModBytes, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read go.mod")
+	}
+	return modfile.ModulePath(goModBytes), nil
+}
+
+func (c *importPolicyChecker) RunCheckCmd(args []string, stdout io.Writer) {
+	c.Check(args, ".", stdout)
+}
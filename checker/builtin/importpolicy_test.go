@@ -0,0 +1,191 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPolicyChecker(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"go.mod": "module example.com/project\n",
+		"internal/api/api.go": `package api
+
+import (
+	"fmt"
+
+	"example.com/project/internal/store"
+	"github.com/pkg/errors"
+)
+`,
+		"internal/api/v2/api.go": `package v2
+
+import "example.com/project/internal/store/sql"
+`,
+		"internal/store/store.go": `package store
+
+import (
+	"unsafe"
+
+	"github.com/other/lib"
+)
+`,
+	})
+
+	checker, err := newImportPolicyChecker([]byte(`rules:
+  - id: no-unsafe
+    banned: [unsafe]
+  - id: api-layering
+    dirs: [internal/api]
+    banned: [./internal/store/...]
+    message: the API layer must not depend on the store
+  - id: store-deps
+    dirs: [internal/store]
+    allowed: [github.com/pkg/...]
+`))
+	require.NoError(t, err)
+	got := checkIssues(checker, []string{"./internal/api", "./internal/api/v2", "./internal/store"}, projectDir)
+	assert.Equal(t, []okgo.Issue{
+		{
+			Path:    "internal/api/api.go",
+			Line:    6,
+			Col:     2,
+			Content: `the API layer must not depend on the store: import "example.com/project/internal/store" is banned (matches "./internal/store/...")`,
+			Rule:    "api-layering",
+		},
+		{
+			Path:    "internal/api/v2/api.go",
+			Line:    3,
+			Col:     8,
+			Content: `the API layer must not depend on the store: import "example.com/project/internal/store/sql" is banned (matches "./internal/store/...")`,
+			Rule:    "api-layering",
+		},
+		{
+			Path:    "internal/store/store.go",
+			Line:    4,
+			Col:     2,
+			Content: `import "unsafe" is banned (matches "unsafe")`,
+			Rule:    "no-unsafe",
+		},
+		{
+			Path:    "internal/store/store.go",
+			Line:    6,
+			Col:     2,
+			Content: `import "github.com/other/lib" is not allowed (allowed: [github.com/pkg/...])`,
+			Rule:    "store-deps",
+		},
+	}, got)
+}
+
+func TestImportPolicyChecker_NoRules(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"foo/foo.go": "package foo\n\nimport \"unsafe\"\n",
+	})
+	// a project that does not configure the check can still run all of the registered checks
+	checker, err := newImportPolicyChecker(nil)
+	require.NoError(t, err)
+	assert.Empty(t, checkIssues(checker, []string{"./foo"}, projectDir))
+}
+
+func TestImportPolicyChecker_NoModulePath(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"foo/foo.go": "package foo\n\nimport \"unsafe\"\n",
+	})
+
+	checker, err := newImportPolicyChecker([]byte(`rules:
+  - id: no-unsafe
+    banned: [unsafe]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []okgo.Issue{
+		{Path: "foo/foo.go", Line: 3, Col: 8, Content: `import "unsafe" is banned (matches "unsafe")`, Rule: "no-unsafe"},
+	}, checkIssues(checker, []string{"./foo"}, projectDir))
+
+	// patterns relative to the module cannot be matched if there is no module path
+	checker, err = newImportPolicyChecker([]byte(`rules:
+  - id: no-unsafe
+    banned: [unsafe]
+  - id: layering
+    allowed: [./foo/...]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []okgo.Issue{
+		{
+			Content: fmt.Sprintf(`rule "layering" uses pattern "./foo/...", which is relative to the module of the project, but no module path is declared in %s`, filepath.Join(projectDir, "go.mod")),
+			Error:   true,
+		},
+	}, checkIssues(checker, []string{"./foo"}, projectDir))
+}
+
+func TestImportPolicyChecker_ModulePathWithoutDot(t *testing.T) {
+	projectDir := writeProjectFiles(t, map[string]string{
+		"go.mod": "module project\n",
+		"api/api.go": `package api
+
+import (
+	"fmt"
+
+	"project/store"
+)
+`,
+	})
+
+	// packages of a module whose path does not contain a dot are not treated as part of the standard library
+	checker, err := newImportPolicyChecker([]byte(`rules:
+  - id: api-deps
+    allowed: [github.com/pkg/...]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []okgo.Issue{
+		{
+			Path:    "api/api.go",
+			Line:    6,
+			Col:     2,
+			Content: `import "project/store" is not allowed (allowed: [github.com/pkg/...])`,
+			Rule:    "api-deps",
+		},
+	}, checkIssues(checker, []string{"./api"}, projectDir))
+}
+
+func TestMatchImportPattern(t *testing.T) {
+	for i, tc := range []struct {
+		pattern    string
+		importPath string
+		want       bool
+	}{
+		{"fmt", "fmt", true},
+		{"fmt", "fmt/foo", false},
+		{"github.com/foo/...", "github.com/foo", true},
+		{"github.com/foo/...", "github.com/foo/bar/baz", true},
+		{"github.com/foo/...", "github.com/foobar", false},
+		{"./internal/...", "example.com/project/internal/store", true},
+		{"./...", "example.com/project", true},
+		{"./internal", "example.com/other/internal", false},
+	} {
+		assert.Equal(t, tc.want, matchImportPattern(tc.pattern, tc.importPath, "example.com/project"), "Case %d", i)
+	}
+}
+
+func TestImportPolicyChecker_InvalidConfig(t *testing.T) {
+	assertInvalidConfigs(t, newImportPolicyChecker, []invalidConfigCase{
+		{"rules:\n  - id: a\n    dirs: [foo]\n", `invalid rule "a": at least one of banned and allowed must be specified`},
+	})
+}
@@ -15,6 +15,7 @@
 package checker
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -26,11 +27,12 @@ import (
 )
 
 type assetChecker struct {
-	assetPath       string
-	cfgYML          string
-	checkerType     okgo.CheckerType
-	checkerPriority okgo.CheckerPriority
-	checkerMultiCPU okgo.CheckerMultiCPU
+	assetPath        string
+	cfgYML           string
+	checkerType      okgo.CheckerType
+	checkerPriority  okgo.CheckerPriority
+	checkerMultiCPU  okgo.CheckerMultiCPU
+	checkerResources okgo.CheckerResources
 }
 
 func (c *assetChecker) Type() (okgo.CheckerType, error) {
@@ -45,6 +47,10 @@ func (c *assetChecker) MultiCPU() (okgo.CheckerMultiCPU, error) {
 	return c.checkerMultiCPU, nil
 }
 
+func (c *assetChecker) Resources() (okgo.CheckerResources, error) {
+	return c.checkerResources, nil
+}
+
 func (c *assetChecker) VerifyConfig() error {
 	verifyConfigCmd := exec.Command(c.assetPath, verifyConfigCmdName,
 		"--"+commonCmdConfigYMLFlagName, c.cfgYML,
@@ -56,7 +62,11 @@ func (c *assetChecker) VerifyConfig() error {
 }
 
 func (c *assetChecker) Check(pkgs []string, projectDir string, stdout io.Writer) {
-	checkCmd := exec.Command(c.assetPath, append([]string{
+	c.CheckWithContext(context.Background(), pkgs, projectDir, stdout)
+}
+
+func (c *assetChecker) CheckWithContext(ctx context.Context, pkgs []string, projectDir string, stdout io.Writer) {
+	checkCmd := CommandContext(ctx, c.assetPath, append([]string{
 		checkCmdName,
 		"--" + commonCmdConfigYMLFlagName, c.cfgYML,
 		"--" + pluginapi.ProjectDirFlagName, projectDir,
@@ -65,6 +75,10 @@ func (c *assetChecker) Check(pkgs []string, projectDir string, stdout io.Writer)
 	checkCmd.Stderr = stdout
 
 	if err := checkCmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			// check was cancelled
+			return
+		}
 		// if running check failed, write failure as its own issue
 		okgo.WriteErrorAsIssue(err, stdout)
 	}
@@ -15,6 +15,7 @@
 package checkerfactory
 
 import (
+	"encoding/json"
 	"sort"
 
 	"github.com/palantir/okgo/checker"
@@ -25,6 +26,8 @@ import (
 type checkerFactoryImpl struct {
 	types                  []okgo.CheckerType
 	checkerCreators        map[okgo.CheckerType]checker.CreatorFunction
+	checkerTags            map[okgo.CheckerType][]string
+	checkerConfigSchemas   map[okgo.CheckerType]json.RawMessage
 	checkerConfigUpgraders map[okgo.CheckerType]okgo.ConfigUpgrader
 }
 
@@ -40,6 +43,20 @@ func (f *checkerFactoryImpl) NewChecker(checkerType okgo.CheckerType, cfgYMLByte
 	return creatorFn(cfgYMLBytes)
 }
 
+func (f *checkerFactoryImpl) Tags(checkerType okgo.CheckerType) ([]string, error) {
+	if _, ok := f.checkerCreators[checkerType]; !ok {
+		return nil, errors.Errorf("no checker registered for checker type %q (registered checkers: %v)", checkerType, f.types)
+	}
+	return f.checkerTags[checkerType], nil
+}
+
+func (f *checkerFactoryImpl) ConfigSchema(checkerType okgo.CheckerType) (json.RawMessage, error) {
+	if _, ok := f.checkerCreators[checkerType]; !ok {
+		return nil, errors.Errorf("no checker registered for checker type %q (registered checkers: %v)", checkerType, f.types)
+	}
+	return f.checkerConfigSchemas[checkerType], nil
+}
+
 func (f *checkerFactoryImpl) ConfigUpgrader(typeName okgo.CheckerType) (okgo.ConfigUpgrader, error) {
 	if _, ok := f.checkerCreators[typeName]; !ok {
 		return nil, errors.Errorf("check %q not registered (registered checks: %v)", typeName, f.types)
@@ -53,9 +70,17 @@ func (f *checkerFactoryImpl) ConfigUpgrader(typeName okgo.CheckerType) (okgo.Con
 
 func New(providedCheckerCreators []checker.Creator, providedConfigUpgraders []okgo.ConfigUpgrader) (okgo.CheckerFactory, error) {
 	checkerCreators := make(map[okgo.CheckerType]checker.CreatorFunction)
+	checkerTags := make(map[okgo.CheckerType][]string)
+	checkerConfigSchemas := make(map[okgo.CheckerType]json.RawMessage)
 	var checkers []okgo.CheckerType
 	for _, currCreator := range providedCheckerCreators {
 		checkerCreators[currCreator.Type()] = currCreator.Creator()
+		if tagsCreator, ok := currCreator.(checker.TagsCreator); ok {
+			checkerTags[currCreator.Type()] = tagsCreator.Tags()
+		}
+		if configSchemaCreator, ok := currCreator.(checker.ConfigSchemaCreator); ok {
+			checkerConfigSchemas[currCreator.Type()] = configSchemaCreator.ConfigSchema()
+		}
 		checkers = append(checkers, currCreator.Type())
 	}
 	sort.Sort(okgo.ByCheckerType(checkers))
@@ -67,6 +92,8 @@ func New(providedCheckerCreators []checker.Creator, providedConfigUpgraders []ok
 	return &checkerFactoryImpl{
 		types:                  checkers,
 		checkerCreators:        checkerCreators,
+		checkerTags:            checkerTags,
+		checkerConfigSchemas:   checkerConfigSchemas,
 		checkerConfigUpgraders: configUpgraders,
 	}, nil
 }
@@ -15,16 +15,18 @@
 package checker
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sort"
 	"sync"
 
+	"github.com/palantir/okgo/checker/lineparser"
 	"github.com/palantir/okgo/okgo"
+	"github.com/palantir/okgo/okgo/trace"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
@@ -38,11 +40,43 @@ type Creator interface {
 	Creator() CreatorFunction
 }
 
+// ResourcesCreator is a Creator that declares the resources used by the check of its checker. Creators returned by
+// NewCreatorWithParams implement this interface.
+type ResourcesCreator interface {
+	Creator
+
+	// Resources returns the resources used by the check of the checker.
+	Resources() okgo.CheckerResources
+}
+
+// TagsCreator is a Creator that advertises tags for its checker. Tags describe the nature of a check (for example,
+// "style", "correctness" or "slow") and can be used to select the checks that are run. Creators returned by
+// NewCreatorWithParams implement this interface.
+type TagsCreator interface {
+	Creator
+
+	// Tags returns the tags of the checker.
+	Tags() []string
+}
+
+// ConfigSchemaCreator is a Creator that provides the JSON Schema for the configuration of its checker. Creators
+// returned by NewCreatorWithParams implement this interface.
+type ConfigSchemaCreator interface {
+	Creator
+
+	// ConfigSchema returns the JSON Schema for the "config" block of the checker's configuration. Returns nil if the
+	// checker does not provide a schema.
+	ConfigSchema() json.RawMessage
+}
+
 type creatorStruct struct {
-	checkerType okgo.CheckerType
-	priority    okgo.CheckerPriority
-	multiCPU    okgo.CheckerMultiCPU
-	creator     CreatorFunction
+	checkerType  okgo.CheckerType
+	priority     okgo.CheckerPriority
+	multiCPU     okgo.CheckerMultiCPU
+	resources    okgo.CheckerResources
+	tags         []string
+	configSchema json.RawMessage
+	creator      CreatorFunction
 }
 
 func (c *creatorStruct) Type() okgo.CheckerType {
@@ -57,12 +91,64 @@ func (c *creatorStruct) MultiCPU() okgo.CheckerMultiCPU {
 	return c.multiCPU
 }
 
+func (c *creatorStruct) Resources() okgo.CheckerResources {
+	return c.resources
+}
+
+func (c *creatorStruct) Tags() []string {
+	return c.tags
+}
+
+func (c *creatorStruct) ConfigSchema() json.RawMessage {
+	return c.configSchema
+}
+
 func (c *creatorStruct) Creator() CreatorFunction {
 	return c.creator
 }
 
+type CreatorParam interface {
+	apply(c *creatorStruct)
+}
+
+type creatorParamFunc func(*creatorStruct)
+
+func (f creatorParamFunc) apply(c *creatorStruct) {
+	f(c)
+}
+
+func CreatorParamMultiCPU(multiCPU okgo.CheckerMultiCPU) CreatorParam {
+	return creatorParamFunc(func(c *creatorStruct) {
+		c.multiCPU = multiCPU
+	})
+}
+
+// CreatorParamResources specifies the resources used by the checker. If the CPU of the provided resources is
+// non-zero, it is used to schedule the checker instead of the MultiCPU value.
+func CreatorParamResources(resources okgo.CheckerResources) CreatorParam {
+	return creatorParamFunc(func(c *creatorStruct) {
+		c.resources = resources
+	})
+}
+
+// CreatorParamTags specifies the tags for the checker. Tags describe the nature of a check (for example, "style",
+// "correctness" or "slow") and can be used to select the checks that are run.
+func CreatorParamTags(tags ...string) CreatorParam {
+	return creatorParamFunc(func(c *creatorStruct) {
+		c.tags = tags
+	})
+}
+
+// CreatorParamConfigSchema specifies the JSON Schema for the "config" block of the checker's configuration. The
+// provided value must be a valid JSON document.
+func CreatorParamConfigSchema(schema json.RawMessage) CreatorParam {
+	return creatorParamFunc(func(c *creatorStruct) {
+		c.configSchema = schema
+	})
+}
+
 func NewCreator(checkerType okgo.CheckerType, priority okgo.CheckerPriority, creatorFn CreatorFunction) Creator {
-	return NewCreatorWithMultiCPU(checkerType, priority, false, creatorFn)
+	return NewCreatorWithParams(checkerType, priority, creatorFn)
 }
 
 func NewCreatorWithMultiCPU(
@@ -70,12 +156,26 @@ func NewCreatorWithMultiCPU(
 	priority okgo.CheckerPriority,
 	multiCPU okgo.CheckerMultiCPU,
 	creatorFn CreatorFunction) Creator {
-	return &creatorStruct{
+	return NewCreatorWithParams(checkerType, priority, creatorFn, CreatorParamMultiCPU(multiCPU))
+}
+
+func NewCreatorWithParams(
+	checkerType okgo.CheckerType,
+	priority okgo.CheckerPriority,
+	creatorFn CreatorFunction,
+	params ...CreatorParam) Creator {
+	creator := &creatorStruct{
 		checkerType: checkerType,
 		priority:    priority,
-		multiCPU:    multiCPU,
 		creator:     creatorFn,
 	}
+	for _, p := range params {
+		if p == nil {
+			continue
+		}
+		p.apply(creator)
+	}
+	return creator
 }
 
 func AssetCheckerCreators(assetPaths ...string) ([]Creator, []okgo.ConfigUpgrader, error) {
@@ -93,20 +193,28 @@ func AssetCheckerCreators(assetPaths ...string) ([]Creator, []okgo.ConfigUpgrade
 		checkerPriority := checkerMetadata.checkerPriority
 		checkerMultiCPU := checkerMetadata.checkerMultiCPU
 		checkerTypeToAssets[checkerType] = append(checkerTypeToAssets[checkerType], currAssetPath)
-		checkerCreators = append(checkerCreators, NewCreatorWithMultiCPU(checkerType, checkerPriority, checkerMultiCPU,
+		checkerCreators = append(checkerCreators, NewCreatorWithParams(checkerType, checkerPriority,
 			func(cfgYML []byte) (okgo.Checker, error) {
 				newChecker := assetChecker{
-					assetPath:       currAssetPath,
-					cfgYML:          string(cfgYML),
-					checkerType:     checkerType,
-					checkerPriority: checkerPriority,
-					checkerMultiCPU: checkerMultiCPU,
+					assetPath:        currAssetPath,
+					cfgYML:           string(cfgYML),
+					checkerType:      checkerType,
+					checkerPriority:  checkerPriority,
+					checkerMultiCPU:  checkerMultiCPU,
+					checkerResources: checkerMetadata.checkerResources,
 				}
+				span := trace.Begin(trace.MainLane, "config", "verify-config "+string(checkerType))
+				defer span.End()
 				if err := newChecker.VerifyConfig(); err != nil {
 					return nil, err
 				}
 				return &newChecker, nil
-			}))
+			},
+			CreatorParamMultiCPU(checkerMultiCPU),
+			CreatorParamResources(checkerMetadata.checkerResources),
+			CreatorParamTags(checkerMetadata.checkerTags...),
+			CreatorParamConfigSchema(checkerMetadata.checkerConfigSchema),
+		))
 		configUpgraders = append(configUpgraders, &assetConfigUpgrader{
 			typeName:  checkerType,
 			assetPath: currAssetPath,
@@ -128,12 +236,19 @@ func AssetCheckerCreators(assetPaths ...string) ([]Creator, []okgo.ConfigUpgrade
 }
 
 type checkerMetadata struct {
-	checkerType     okgo.CheckerType
-	checkerPriority okgo.CheckerPriority
-	checkerMultiCPU okgo.CheckerMultiCPU
+	checkerType      okgo.CheckerType
+	checkerPriority  okgo.CheckerPriority
+	checkerMultiCPU  okgo.CheckerMultiCPU
+	checkerResources okgo.CheckerResources
+	checkerTags      []string
+	// checkerConfigSchema is nil if the asset does not provide a schema for its configuration.
+	checkerConfigSchema json.RawMessage
 }
 
 func determineCheckerMetadataForPaths(assetPaths []string) (map[string]checkerMetadata, error) {
+	span := trace.Begin(trace.MainLane, "assets", "load asset metadata")
+	defer span.End()
+
 	checkerMetadatas := make(map[string]checkerMetadata)
 	var (
 		mapLock sync.Mutex
@@ -141,7 +256,11 @@ func determineCheckerMetadataForPaths(assetPaths []string) (map[string]checkerMe
 	)
 	for _, assetPathSingle := range assetPaths {
 		assetPath := assetPathSingle
+		lane := trace.NewLane("asset " + filepath.Base(assetPath))
 		g.Go(func() error {
+			assetSpan := trace.Begin(lane, "assets", "load metadata for "+filepath.Base(assetPath))
+			defer assetSpan.End()
+
 			checkerMetadataForAsset, err := determineCheckerMetadata(assetPath)
 			if err != nil {
 				return err
@@ -158,7 +277,20 @@ func determineCheckerMetadataForPaths(assetPaths []string) (map[string]checkerMe
 	return checkerMetadatas, nil
 }
 
+// determineCheckerMetadata returns the metadata of the asset at the provided path. The metadata is determined using the
+// "metadata" command of the asset if it supports it, and using a separate command for each value otherwise.
 func determineCheckerMetadata(assetPath string) (checkerMetadata, error) {
+	if metadata, ok := getAssetMetadata(assetPath); ok {
+		return checkerMetadata{
+			checkerType:         metadata.Type,
+			checkerPriority:     metadata.Priority,
+			checkerMultiCPU:     metadata.MultiCPU,
+			checkerResources:    metadata.Resources,
+			checkerTags:         metadata.Tags,
+			checkerConfigSchema: metadata.ConfigSchema,
+		}, nil
+	}
+
 	nameCmd := exec.Command(assetPath, typeCmdName)
 	outputBytes, err := runCommand(nameCmd)
 	if err != nil {
@@ -178,12 +310,30 @@ func determineCheckerMetadata(assetPath string) (checkerMetadata, error) {
 		return checkerMetadata{}, errors.Wrapf(err, "failed to unmarshal JSON")
 	}
 	return checkerMetadata{
-		checkerType:     checkerType,
-		checkerPriority: checkerPriority,
-		checkerMultiCPU: getCheckerMultiCPU(assetPath),
+		checkerType:         checkerType,
+		checkerPriority:     checkerPriority,
+		checkerMultiCPU:     getCheckerMultiCPU(assetPath),
+		checkerResources:    getCheckerResources(assetPath),
+		checkerTags:         getCheckerTags(assetPath),
+		checkerConfigSchema: getCheckerConfigSchema(assetPath),
 	}, nil
 }
 
+// getAssetMetadata returns the output of the "metadata" command of the asset. Returns false if the asset does not
+// support the "metadata" command.
+func getAssetMetadata(assetPath string) (assetMetadata, bool) {
+	metadataCmd := exec.Command(assetPath, metadataCmdName)
+	outputBytes, err := runCommand(metadataCmd)
+	if err != nil {
+		return assetMetadata{}, false
+	}
+	var metadata assetMetadata
+	if err := json.Unmarshal(outputBytes, &metadata); err != nil || metadata.Type == "" {
+		return assetMetadata{}, false
+	}
+	return metadata, true
+}
+
 func getCheckerMultiCPU(assetPath string) okgo.CheckerMultiCPU {
 	multiCPUCmd := exec.Command(assetPath, multiCPUCmdName)
 	outputBytes, err := runCommand(multiCPUCmd)
@@ -197,12 +347,63 @@ func getCheckerMultiCPU(assetPath string) okgo.CheckerMultiCPU {
 	return checkerPriority
 }
 
+// getCheckerResources returns the resources declared by the asset. Returns empty resources if the asset does not
+// support the "resources" command.
+func getCheckerResources(assetPath string) okgo.CheckerResources {
+	resourcesCmd := exec.Command(assetPath, resourcesCmdName)
+	outputBytes, err := runCommand(resourcesCmd)
+	if err != nil {
+		return okgo.CheckerResources{}
+	}
+	var checkerResources okgo.CheckerResources
+	if err := json.Unmarshal(outputBytes, &checkerResources); err != nil {
+		return okgo.CheckerResources{}
+	}
+	return checkerResources
+}
+
+// getCheckerTags returns the tags advertised by the asset. Returns nil if the asset does not support the "tags"
+// command.
+func getCheckerTags(assetPath string) []string {
+	tagsCmd := exec.Command(assetPath, tagsCmdName)
+	outputBytes, err := runCommand(tagsCmd)
+	if err != nil {
+		return nil
+	}
+	var checkerTags []string
+	if err := json.Unmarshal(outputBytes, &checkerTags); err != nil {
+		return nil
+	}
+	return checkerTags
+}
+
+// getCheckerConfigSchema returns the JSON Schema for the configuration of the asset. Returns nil if the asset does not
+// support the "config-schema" command or if its output is not a JSON document.
+func getCheckerConfigSchema(assetPath string) json.RawMessage {
+	configSchemaCmd := exec.Command(assetPath, configSchemaCmdName)
+	outputBytes, err := runCommand(configSchemaCmd)
+	if err != nil || !json.Valid(outputBytes) {
+		return nil
+	}
+	return outputBytes
+}
+
 // RunCommandAndStreamOutput runs the provided exec.Cmd. The output that is generated to Stdout and Stderr for the
 // command is processed in a separate goroutine. Each line is provided to the provided lineParser and the JSON
 // representation of the issue returned by the parser is written to the provided stdout. This function will not return
 // until the underlying command has finished executing and all of the generated output has been processed and written
 // to the provided stdout.
 func RunCommandAndStreamOutput(cmd *exec.Cmd, lineParser func(line string) okgo.Issue, stdout io.Writer) {
+	RunCommandAndStreamWithParser(cmd, lineparser.FromFunc(func(line, _ string) okgo.Issue {
+		return lineParser(line)
+	})(""), stdout)
+}
+
+// RunCommandAndStreamWithParser runs the provided exec.Cmd in the same manner as RunCommandAndStreamOutput, but the
+// output is parsed using the provided LineParser. The issues returned by the parser are written as they are returned,
+// and the issues returned by Flush are written once all of the output has been parsed. The provided parser should not
+// be used to parse the output of any other command.
+func RunCommandAndStreamWithParser(cmd *exec.Cmd, parser lineparser.LineParser, stdout io.Writer) {
 	pipeR, pipeW, err := os.Pipe()
 	if err != nil {
 		okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to create pipe"), stdout)
@@ -214,23 +415,12 @@ func RunCommandAndStreamOutput(cmd *exec.Cmd, lineParser func(line string) okgo.
 
 	done := make(chan bool)
 	go func() {
-		scanner := bufio.NewScanner(pipeR)
-		for scanner.Scan() {
-			issue := lineParser(scanner.Text())
-			if issue == (okgo.Issue{}) {
-				// skip empty issues
-				continue
-			}
-			issueJSONBytes, err := json.Marshal(issue)
-			if err != nil {
-				okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to marshal issue %+v as JSON", issue), stdout)
-				continue
-			}
-			_, _ = fmt.Fprintln(stdout, string(issueJSONBytes))
-		}
-		if err := scanner.Err(); err != nil {
-			okgo.WriteErrorAsIssue(errors.Wrapf(err, "scanner error encountered while reading output"), stdout)
+		if err := okgo.ReadLines(pipeR, func(line string) {
+			writeIssues(parser.ParseLine(line), stdout)
+		}); err != nil {
+			okgo.WriteErrorAsIssue(errors.Wrapf(err, "error encountered while reading output"), stdout)
 		}
+		writeIssues(parser.Flush(), stdout)
 		done <- true
 	}()
 
@@ -255,3 +445,19 @@ func RunCommandAndStreamOutput(cmd *exec.Cmd, lineParser func(line string) okgo.
 	// wait for all output to be processed
 	<-done
 }
+
+// writeIssues writes the JSON representation of each of the provided issues that is not empty to stdout.
+func writeIssues(issues []okgo.Issue, stdout io.Writer) {
+	for _, issue := range issues {
+		if issue.IsEmpty() {
+			// skip empty issues
+			continue
+		}
+		issueJSONBytes, err := json.Marshal(issue)
+		if err != nil {
+			okgo.WriteErrorAsIssue(errors.Wrapf(err, "failed to marshal issue %+v as JSON", issue), stdout)
+			continue
+		}
+		_, _ = fmt.Fprintln(stdout, string(issueJSONBytes))
+	}
+}
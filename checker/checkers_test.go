@@ -0,0 +1,158 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package checker
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/palantir/okgo/checker/lineparser"
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetCheckerCreators_Resources(t *testing.T) {
+	assetsDir := t.TempDir()
+	resourcesAsset := writeTestAsset(t, assetsDir, "resources-asset", `case "$1" in
+  type) printf '"foo"' ;;
+  priority) printf '3' ;;
+  resources) printf '{"cpu":2,"memoryMB":512}' ;;
+  verify-config) ;;
+  *) echo "unknown command $1" >&2; exit 1 ;;
+esac
+`)
+	noResourcesAsset := writeTestAsset(t, assetsDir, "no-resources-asset", `case "$1" in
+  type) printf '"bar"' ;;
+  priority) printf '4' ;;
+  verify-config) ;;
+  *) echo "unknown command $1" >&2; exit 1 ;;
+esac
+`)
+
+	creators, _, err := AssetCheckerCreators(resourcesAsset, noResourcesAsset)
+	require.NoError(t, err)
+	require.Len(t, creators, 2)
+
+	for i, want := range []okgo.CheckerResources{
+		{CPU: 2, MemoryMB: 512},
+		{},
+	} {
+		resourcesCreator, ok := creators[i].(ResourcesCreator)
+		require.True(t, ok)
+		assert.Equal(t, want, resourcesCreator.Resources())
+
+		checker, err := creators[i].Creator()(nil)
+		require.NoError(t, err)
+		resourceChecker, ok := checker.(okgo.ResourceChecker)
+		require.True(t, ok)
+		resources, err := resourceChecker.Resources()
+		require.NoError(t, err)
+		assert.Equal(t, want, resources)
+	}
+}
+
+func TestDetermineCheckerMetadata(t *testing.T) {
+	assetsDir := t.TempDir()
+	invocationsFile := filepath.Join(assetsDir, "invocations.txt")
+	metadataAsset := writeTestAsset(t, assetsDir, "metadata-asset", `echo "$1" >> `+invocationsFile+`
+case "$1" in
+  metadata) printf '{"type":"foo","priority":3,"multiCPU":true,"resources":{"cpu":2,"memoryMB":512},"tags":["style"],"configSchema":{"type":"object"}}' ;;
+  *) echo "unknown command $1" >&2; exit 1 ;;
+esac
+`)
+	legacyAsset := writeTestAsset(t, assetsDir, "legacy-asset", `case "$1" in
+  type) printf '"bar"' ;;
+  priority) printf '4' ;;
+  multicpu) printf 'true' ;;
+  resources) printf '{"cpu":2,"memoryMB":512}' ;;
+  tags) printf '["slow"]' ;;
+  config-schema) printf '{"type":"null"}' ;;
+  *) echo "unknown command $1" >&2; exit 1 ;;
+esac
+`)
+
+	metadata, err := determineCheckerMetadata(metadataAsset)
+	require.NoError(t, err)
+	assert.Equal(t, checkerMetadata{
+		checkerType:         "foo",
+		checkerPriority:     3,
+		checkerMultiCPU:     true,
+		checkerResources:    okgo.CheckerResources{CPU: 2, MemoryMB: 512},
+		checkerTags:         []string{"style"},
+		checkerConfigSchema: json.RawMessage(`{"type":"object"}`),
+	}, metadata)
+	// the values provided by the "metadata" command are not queried using separate commands
+	invocations, err := os.ReadFile(invocationsFile)
+	require.NoError(t, err)
+	assert.NotContains(t, strings.Fields(string(invocations)), resourcesCmdName)
+	assert.NotContains(t, strings.Fields(string(invocations)), tagsCmdName)
+	assert.NotContains(t, strings.Fields(string(invocations)), configSchemaCmdName)
+
+	// assets that do not support the "metadata" command provide each value using a separate command
+	metadata, err = determineCheckerMetadata(legacyAsset)
+	require.NoError(t, err)
+	assert.Equal(t, checkerMetadata{
+		checkerType:         "bar",
+		checkerPriority:     4,
+		checkerMultiCPU:     true,
+		checkerResources:    okgo.CheckerResources{CPU: 2, MemoryMB: 512},
+		checkerTags:         []string{"slow"},
+		checkerConfigSchema: json.RawMessage(`{"type":"null"}`),
+	}, metadata)
+}
+
+func writeTestAsset(t *testing.T, dir, name, script string) string {
+	assetPath := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(assetPath, []byte("#!/bin/sh\n"+script), 0755))
+	return assetPath
+}
+
+func TestRunCommandAndStreamWithParser(t *testing.T) {
+	cmd := exec.Command("sh", "-c", `printf 'foo.go:1:1: panic\n\tgoroutine 1 [running]:\n\tmain.main()\nbar.go:2:2: last\n  at the end\n'`)
+	buf := &bytes.Buffer{}
+	RunCommandAndStreamWithParser(cmd, lineparser.Folding(lineparser.Default, lineparser.IsContinuationLine)("/wd"), buf)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2, buf.String())
+	assert.Equal(t, okgo.Issue{Path: "foo.go", Line: 1, Col: 1, Content: "panic\n\tgoroutine 1 [running]:\n\tmain.main()"}, okgo.NewIssueFromJSON(lines[0]))
+	// the pending issue is written once the output ends
+	assert.Equal(t, okgo.Issue{Path: "bar.go", Line: 2, Col: 2, Content: "last\n  at the end"}, okgo.NewIssueFromJSON(lines[1]))
+}
+
+func TestRunCommandAndStreamOutput_LongLines(t *testing.T) {
+	// lines are much larger than the default maximum token size of bufio.Scanner
+	longContent := strings.Repeat("x", 5*1024*1024)
+	outputFile := filepath.Join(t.TempDir(), "output.txt")
+	require.NoError(t, os.WriteFile(outputFile, []byte("foo.go:1:1: "+longContent+"\r\nbar.go:2:2: after"), 0644))
+
+	buf := &bytes.Buffer{}
+	RunCommandAndStreamOutput(exec.Command("cat", outputFile), func(line string) okgo.Issue {
+		return okgo.NewIssueFromLine(line, "/wd")
+	}, buf)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, okgo.Issue{Path: "foo.go", Line: 1, Col: 1, Content: longContent}, okgo.NewIssueFromJSON(lines[0]))
+	// the output that follows a long line is not dropped, even if it does not end in a newline
+	assert.Equal(t, okgo.Issue{Path: "bar.go", Line: 2, Col: 2, Content: "after"}, okgo.NewIssueFromJSON(lines[1]))
+}
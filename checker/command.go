@@ -0,0 +1,36 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"os/exec"
+	"time"
+)
+
+// commandWaitDelay is the amount of time that Wait waits for the output of a command to be closed after the command
+// exits or its context is done.
+const commandWaitDelay = 5 * time.Second
+
+// CommandContext returns an exec.Cmd that runs the named program with the given arguments in the same manner as
+// exec.CommandContext. On platforms that support process groups, the command is started in its own process group and
+// the entire group is killed when the context is done, so processes started by the command do not outlive it and keep
+// its output open.
+func CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	setProcessGroup(cmd)
+	cmd.WaitDelay = commandWaitDelay
+	return cmd
+}
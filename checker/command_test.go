@@ -0,0 +1,55 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package checker
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandContext_KillsProcessGroup(t *testing.T) {
+	pipeR, pipeW, err := os.Pipe()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// the background process inherits the output of the command and keeps it open unless it is killed with the command
+	cmd := CommandContext(ctx, "sh", "-c", "sleep 60 & wait")
+	cmd.Stdout = pipeW
+	cmd.Stderr = pipeW
+	require.NoError(t, cmd.Start())
+	require.NoError(t, pipeW.Close())
+
+	readDone := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, pipeR)
+		close(readDone)
+	}()
+
+	cancel()
+	assert.Error(t, cmd.Wait())
+	select {
+	case <-readDone:
+	case <-time.After(10 * time.Second):
+		t.Fatal("output of command was not closed after its context was done")
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lineparser provides LineParser, which converts the lines of output of a check into issues, along with parsers
+// for the output of common Go tools. Paths in the output that are absolute are converted to paths relative to the
+// working directory provided when the parser is created.
+package lineparser
+
+import (
+	"encoding/json"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/palantir/okgo/okgo"
+)
+
+var fileLineRegexp = regexp.MustCompile(`^(.+?):(\d+)(?::(\d+))?: (.*)$`)
+
+// FileLine returns a parser for lines of the form "path:line:col: message" or "path:line: message" (output that does
+// not include a column). Lines that do not match either form are returned as issues whose content is the entire line.
+func FileLine(wd string) LineParser {
+	return FromFunc(parseFileLine)(wd)
+}
+
+func parseFileLine(line, wd string) okgo.Issue {
+	matches := fileLineRegexp.FindStringSubmatch(line)
+	if matches == nil {
+		return okgo.Issue{
+			Content: line,
+		}
+	}
+	issue := okgo.Issue{
+		Path:    relPath(matches[1], wd),
+		Content: matches[4],
+	}
+	issue.Line, _ = strconv.Atoi(matches[2])
+	if matches[3] != "" {
+		issue.Col, _ = strconv.Atoi(matches[3])
+	}
+	return issue
+}
+
+// GoBuild returns a parser for the output of "go build" (and other commands that report compilation errors, such as "go
+// vet"). The "# package" lines that precede the errors for each package are ignored, and errors are parsed in the same
+// manner as FileLine.
+func GoBuild(wd string) LineParser {
+	return FromFunc(parseGoBuild)(wd)
+}
+
+func parseGoBuild(line, wd string) okgo.Issue {
+	if strings.HasPrefix(line, "# ") {
+		return okgo.Issue{}
+	}
+	return parseFileLine(strings.TrimPrefix(line, "vet: "), wd)
+}
+
+type staticcheckLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+type staticcheckIssue struct {
+	Code     string              `json:"code"`
+	Location staticcheckLocation `json:"location"`
+	End      staticcheckLocation `json:"end"`
+	Message  string              `json:"message"`
+}
+
+// StaticcheckJSON returns a parser for the output of "staticcheck -f json", in which each line is a JSON object that
+// describes a single issue. The code of the issue (for example, "SA4006") is used as its rule and the end of its
+// location is used as the end of the issue. Lines that are not JSON objects are returned as issues whose content is the
+// entire line.
+func StaticcheckJSON(wd string) LineParser {
+	return FromFunc(parseStaticcheckJSON)(wd)
+}
+
+func parseStaticcheckJSON(line, wd string) okgo.Issue {
+	var in staticcheckIssue
+	if err := json.Unmarshal([]byte(line), &in); err != nil {
+		return okgo.Issue{
+			Content: line,
+		}
+	}
+	return okgo.Issue{
+		Path:    relPath(in.Location.File, wd),
+		Line:    in.Location.Line,
+		Col:     in.Location.Column,
+		Content: in.Message,
+		EndLine: in.End.Line,
+		EndCol:  in.End.Column,
+		Rule:    in.Code,
+	}
+}
+
+type golangCILintReport struct {
+	Issues []struct {
+		FromLinter string `json:"FromLinter"`
+		Text       string `json:"Text"`
+		Pos        struct {
+			Filename string `json:"Filename"`
+			Line     int    `json:"Line"`
+			Column   int    `json:"Column"`
+		} `json:"Pos"`
+	} `json:"Issues"`
+}
+
+// GolangCILintJSON returns a parser for the output of "golangci-lint run" with JSON output, which writes all of the
+// issues as a single JSON object on one line. The name of the linter that reported an issue is used as its rule. Lines
+// that are not JSON objects are returned as issues whose content is the entire line.
+func GolangCILintJSON(wd string) LineParser {
+	return FromIssuesFunc(parseGolangCILintJSON)(wd)
+}
+
+func parseGolangCILintJSON(line, wd string) []okgo.Issue {
+	var report golangCILintReport
+	if err := json.Unmarshal([]byte(line), &report); err != nil {
+		return []okgo.Issue{{
+			Content: line,
+		}}
+	}
+	var issues []okgo.Issue
+	for _, in := range report.Issues {
+		issues = append(issues, okgo.Issue{
+			Path:    relPath(in.Pos.Filename, wd),
+			Line:    in.Pos.Line,
+			Col:     in.Pos.Column,
+			Content: in.Text,
+			Rule:    in.FromLinter,
+		})
+	}
+	return issues
+}
+
+var posnRegexp = regexp.MustCompile(`^(.+?):(\d+)(?::(\d+))?$`)
+
+type goVetDiagnostic struct {
+	Posn    string `json:"posn"`
+	End     string `json:"end"`
+	Message string `json:"message"`
+	Related []struct {
+		Posn    string `json:"posn"`
+		End     string `json:"end"`
+		Message string `json:"message"`
+	} `json:"related"`
+}
+
+// GoVetJSON returns a parser for the output of "go vet -json", which writes the diagnostics for each package as an
+// indented JSON object that spans multiple lines. The lines of each object are buffered until the object is complete,
+// at which point the object is decoded and the issues for all of its diagnostics are returned. The name of the analyzer
+// that reported a diagnostic is used as its rule, and the end position and related information of the diagnostic are
+// used as the end and related locations of the issue. Output that is not part of a JSON object (such as compilation
+// errors) is parsed in the same manner as GoBuild.
+func GoVetJSON(wd string) LineParser {
+	return &goVetJSONParser{
+		wd: wd,
+	}
+}
+
+type goVetJSONParser struct {
+	wd string
+
+	// lines are the lines of the JSON object that is being parsed
+	lines []string
+	// depth is the nesting depth of the JSON object that is being parsed at the end of the buffered lines
+	depth int
+}
+
+func (p *goVetJSONParser) ParseLine(line string) []okgo.Issue {
+	if len(p.lines) == 0 && strings.TrimSpace(line) != "{" {
+		if issue := parseGoBuild(line, p.wd); !issue.IsEmpty() {
+			return []okgo.Issue{issue}
+		}
+		return nil
+	}
+	p.lines = append(p.lines, line)
+	p.depth += jsonDepthChange(line)
+	if p.depth > 0 {
+		return nil
+	}
+	object := strings.Join(p.lines, "\n")
+	p.lines = nil
+	p.depth = 0
+	return p.parseObject(object)
+}
+
+func (p *goVetJSONParser) Flush() []okgo.Issue {
+	if len(p.lines) == 0 {
+		return nil
+	}
+	// the output ended before the object was complete
+	issue := okgo.Issue{
+		Content: strings.Join(p.lines, "\n"),
+	}
+	p.lines = nil
+	p.depth = 0
+	return []okgo.Issue{issue}
+}
+
+// parseObject returns the issues for the diagnostics in the provided JSON object, which maps the path of each package
+// to the diagnostics reported by each analyzer. An analyzer that fails reports an object with an "error" field rather
+// than its diagnostics. Packages and analyzers are returned in sorted order.
+func (p *goVetJSONParser) parseObject(object string) []okgo.Issue {
+	var pkgs map[string]map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(object), &pkgs); err != nil {
+		return []okgo.Issue{{
+			Content: object,
+		}}
+	}
+	var issues []okgo.Issue
+	for _, pkg := range slices.Sorted(maps.Keys(pkgs)) {
+		analyzers := pkgs[pkg]
+		for _, analyzer := range slices.Sorted(maps.Keys(analyzers)) {
+			var diagnostics []goVetDiagnostic
+			if err := json.Unmarshal(analyzers[analyzer], &diagnostics); err != nil {
+				var analyzerErr struct {
+					Error string `json:"error"`
+				}
+				if err := json.Unmarshal(analyzers[analyzer], &analyzerErr); err != nil || analyzerErr.Error == "" {
+					continue
+				}
+				issues = append(issues, okgo.Issue{
+					Content: analyzerErr.Error,
+					Rule:    analyzer,
+				})
+				continue
+			}
+			for _, diagnostic := range diagnostics {
+				issue := okgo.Issue{
+					Content: diagnostic.Message,
+					Rule:    analyzer,
+				}
+				issue.Path, issue.Line, issue.Col = parsePosn(diagnostic.Posn, p.wd)
+				if diagnostic.End != "" {
+					_, issue.EndLine, issue.EndCol = parsePosn(diagnostic.End, p.wd)
+				}
+				for _, related := range diagnostic.Related {
+					relatedLocation := okgo.RelatedLocation{
+						Message: related.Message,
+					}
+					relatedLocation.Path, relatedLocation.Line, relatedLocation.Col = parsePosn(related.Posn, p.wd)
+					if related.End != "" {
+						_, relatedLocation.EndLine, relatedLocation.EndCol = parsePosn(related.End, p.wd)
+					}
+					issue.Related = append(issue.Related, relatedLocation)
+				}
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
+
+// jsonDepthChange returns the change in nesting depth of JSON objects and arrays caused by the provided line. JSON
+// strings cannot contain newlines, so every line starts outside of a string.
+func jsonDepthChange(line string) int {
+	var change int
+	var inString, escaped bool
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && r == '\\':
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case inString:
+			// brackets in strings do not change the depth
+		case r == '{' || r == '[':
+			change++
+		case r == '}' || r == ']':
+			change--
+		}
+	}
+	return change
+}
+
+// parsePosn parses a position of the form "path:line:col" or "path:line". If the position does not match either form,
+// the entire position is returned as the path.
+func parsePosn(posn, wd string) (string, int, int) {
+	matches := posnRegexp.FindStringSubmatch(posn)
+	if matches == nil {
+		return relPath(posn, wd), 0, 0
+	}
+	line, _ := strconv.Atoi(matches[2])
+	col, _ := strconv.Atoi(matches[3])
+	return relPath(matches[1], wd), line, col
+}
+
+func relPath(path, wd string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
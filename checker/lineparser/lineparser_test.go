@@ -0,0 +1,144 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileLine(t *testing.T) {
+	for i, tc := range []struct {
+		line string
+		want []okgo.Issue
+	}{
+		{"foo.go:5:2: message", []okgo.Issue{{Path: "foo.go", Line: 5, Col: 2, Content: "message"}}},
+		{"foo.go:5: message: with colon", []okgo.Issue{{Path: "foo.go", Line: 5, Content: "message: with colon"}}},
+		{"/wd/foo/foo.go:5: message", []okgo.Issue{{Path: "foo/foo.go", Line: 5, Content: "message"}}},
+		{"not an issue", []okgo.Issue{{Content: "not an issue"}}},
+	} {
+		assert.Equal(t, tc.want, parseLines(FileLine("/wd"), tc.line), "Case %d", i)
+	}
+}
+
+func TestGoBuild(t *testing.T) {
+	for i, tc := range []struct {
+		line string
+		want []okgo.Issue
+	}{
+		{"# example.com/foo", nil},
+		{"./foo.go:3:12: undefined: x", []okgo.Issue{{Path: "./foo.go", Line: 3, Col: 12, Content: "undefined: x"}}},
+		{"vet: foo.go:3:12: undefined: x", []okgo.Issue{{Path: "foo.go", Line: 3, Col: 12, Content: "undefined: x"}}},
+		{"go: updates to go.mod needed", []okgo.Issue{{Content: "go: updates to go.mod needed"}}},
+	} {
+		assert.Equal(t, tc.want, parseLines(GoBuild("/wd"), tc.line), "Case %d", i)
+	}
+}
+
+func TestStaticcheckJSON(t *testing.T) {
+	line := `{"code":"SA4006","severity":"error","location":{"file":"/wd/foo.go","line":5,"column":2},"end":{"file":"/wd/foo.go","line":5,"column":3},"message":"this value of x is never used"}`
+	assert.Equal(t, []okgo.Issue{{
+		Path:    "foo.go",
+		Line:    5,
+		Col:     2,
+		Content: "this value of x is never used",
+		EndLine: 5,
+		EndCol:  3,
+		Rule:    "SA4006",
+	}}, parseLines(StaticcheckJSON("/wd"), line))
+	assert.Equal(t, []okgo.Issue{{Content: "not JSON"}}, parseLines(StaticcheckJSON("/wd"), "not JSON"))
+}
+
+func TestGolangCILintJSON(t *testing.T) {
+	line := `{"Issues":[{"FromLinter":"errcheck","Text":"Error return value is not checked","Pos":{"Filename":"foo.go","Offset":10,"Line":5,"Column":2}},{"FromLinter":"govet","Text":"printf: bad format","Pos":{"Filename":"/wd/bar/bar.go","Offset":20,"Line":7,"Column":3}}],"Report":{}}`
+	assert.Equal(t, []okgo.Issue{
+		{Path: "foo.go", Line: 5, Col: 2, Content: "Error return value is not checked", Rule: "errcheck"},
+		{Path: "bar/bar.go", Line: 7, Col: 3, Content: "printf: bad format", Rule: "govet"},
+	}, parseLines(GolangCILintJSON("/wd"), line))
+	assert.Empty(t, parseLines(GolangCILintJSON("/wd"), `{"Issues":[],"Report":{}}`))
+	assert.Equal(t, []okgo.Issue{{Content: "level=error"}}, parseLines(GolangCILintJSON("/wd"), "level=error"))
+}
+
+func TestGoVetJSON(t *testing.T) {
+	output := `# example.com/foo
+{
+	"example.com/foo": {
+		"copylocks": [
+			{
+				"posn": "/wd/foo.go:8:7",
+				"end": "/wd/foo.go:8:8",
+				"message": "assignment copies lock value to n: sync.Mutex",
+				"related": [
+					{
+						"posn": "/wd/foo.go:5:2",
+						"end": "/wd/foo.go:5:12",
+						"message": "lock declared here"
+					}
+				]
+			}
+		],
+		"printf": [
+			{
+				"posn": "/wd/foo.go:6:14",
+				"message": "fmt.Printf format %d has arg \"x\" of wrong type string",
+				"suggested_fixes": [
+					{
+						"message": "Use %s",
+						"edits": [
+							{
+								"filename": "/wd/foo.go",
+								"start": 60,
+								"end": 62,
+								"new": "%s"
+							}
+						]
+					}
+				]
+			}
+		],
+		"unusedresult": {
+			"error": "analysis failed"
+		}
+	}
+}
+{
+	"example.com/foo/bar": {
+		"composites": [
+			{
+				"posn": "/wd/bar/bar.go:4:6",
+				"message": "struct{} literal uses unkeyed fields: \"}]\" {["
+			}
+		]
+	}
+}
+vet: bar.go:3:12: undefined: x
+{
+	"example.com/foo/baz": {`
+	got := parseLines(GoVetJSON("/wd"), strings.Split(output, "\n")...)
+	assert.Equal(t, []okgo.Issue{
+		{Path: "foo.go", Line: 8, Col: 7, EndLine: 8, EndCol: 8, Content: "assignment copies lock value to n: sync.Mutex", Rule: "copylocks", Related: []okgo.RelatedLocation{
+			{Path: "foo.go", Line: 5, Col: 2, EndLine: 5, EndCol: 12, Message: "lock declared here"},
+		}},
+		{Path: "foo.go", Line: 6, Col: 14, Content: `fmt.Printf format %d has arg "x" of wrong type string`, Rule: "printf"},
+		{Content: "analysis failed", Rule: "unusedresult"},
+		{Path: "bar/bar.go", Line: 4, Col: 6, Content: `struct{} literal uses unkeyed fields: "}]" {[`, Rule: "composites"},
+		{Path: "bar.go", Line: 3, Col: 12, Content: "undefined: x"},
+		// an object that is not complete when the output ends is returned as-is
+		{Content: "{\n\t\"example.com/foo/baz\": {"},
+	}, got)
+}
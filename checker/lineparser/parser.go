@@ -0,0 +1,145 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineparser
+
+import (
+	"strings"
+
+	"github.com/palantir/okgo/okgo"
+)
+
+// LineParser converts the lines of output of a single invocation of a check into issues. A LineParser can retain state
+// between lines, which allows a single issue to span multiple lines of output, so a new LineParser must be used to
+// parse the output of each invocation.
+type LineParser interface {
+	// ParseLine parses the provided line and returns the issues that are complete as a result.
+	ParseLine(line string) []okgo.Issue
+
+	// Flush returns the issues that are still pending once all of the output has been parsed.
+	Flush() []okgo.Issue
+}
+
+// Factory returns a new LineParser for the output of an invocation of a check that was run in the provided working
+// directory. Paths in the output that are absolute are converted to paths relative to the working directory.
+type Factory func(wd string) LineParser
+
+// FromFunc returns a Factory for stateless parsers that convert each line into the issue returned by the provided
+// function. Empty issues are ignored.
+func FromFunc(parseLine func(line, wd string) okgo.Issue) Factory {
+	return FromIssuesFunc(func(line, wd string) []okgo.Issue {
+		return []okgo.Issue{parseLine(line, wd)}
+	})
+}
+
+// FromIssuesFunc returns a Factory for stateless parsers that convert each line into the issues returned by the
+// provided function. This supports output formats in which a single line can describe any number of issues. Empty
+// issues are ignored.
+func FromIssuesFunc(parseLine func(line, wd string) []okgo.Issue) Factory {
+	return func(wd string) LineParser {
+		return &funcLineParser{
+			parseLine: parseLine,
+			wd:        wd,
+		}
+	}
+}
+
+type funcLineParser struct {
+	parseLine func(line, wd string) []okgo.Issue
+	wd        string
+}
+
+func (p *funcLineParser) ParseLine(line string) []okgo.Issue {
+	var issues []okgo.Issue
+	for _, issue := range p.parseLine(line, p.wd) {
+		if !issue.IsEmpty() {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (p *funcLineParser) Flush() []okgo.Issue {
+	return nil
+}
+
+// Default returns a parser that converts each line into an issue using okgo.NewIssueFromLine.
+func Default(wd string) LineParser {
+	return FromFunc(okgo.NewIssueFromLine)(wd)
+}
+
+// Folding returns a Factory for parsers that parse each line using a parser created by the provided Factory, except for
+// lines for which isContinuation returns true: such lines are appended (on a new line) to the content of the last issue
+// returned for the preceding line rather than being parsed. Issues are returned once the line that follows them is not
+// a continuation line. A continuation line that does not follow an issue is parsed normally.
+func Folding(newParser Factory, isContinuation func(line string) bool) Factory {
+	return func(wd string) LineParser {
+		return &foldingLineParser{
+			parser:         newParser(wd),
+			isContinuation: isContinuation,
+		}
+	}
+}
+
+type foldingLineParser struct {
+	parser         LineParser
+	isContinuation func(line string) bool
+
+	pending *okgo.Issue
+}
+
+func (p *foldingLineParser) ParseLine(line string) []okgo.Issue {
+	if p.pending != nil && p.isContinuation(line) {
+		p.pending.Content += "\n" + line
+		return nil
+	}
+	return p.complete(p.parser.ParseLine(line))
+}
+
+func (p *foldingLineParser) Flush() []okgo.Issue {
+	completed := p.complete(p.parser.Flush())
+	if p.pending != nil {
+		completed = append(completed, *p.pending)
+		p.pending = nil
+	}
+	return completed
+}
+
+// complete returns the pending issue and all but the last of the provided issues that are not empty. The last of the
+// provided issues that is not empty becomes the pending issue.
+func (p *foldingLineParser) complete(issues []okgo.Issue) []okgo.Issue {
+	var nonEmpty []okgo.Issue
+	for _, issue := range issues {
+		if !issue.IsEmpty() {
+			nonEmpty = append(nonEmpty, issue)
+		}
+	}
+	var completed []okgo.Issue
+	if p.pending != nil {
+		completed = append(completed, *p.pending)
+		p.pending = nil
+	}
+	if len(nonEmpty) == 0 {
+		return completed
+	}
+	last := nonEmpty[len(nonEmpty)-1]
+	p.pending = &last
+	return append(completed, nonEmpty[:len(nonEmpty)-1]...)
+}
+
+// IsContinuationLine returns true if the provided line is indented or is a "note:" line. Tools such as "go build" and
+// many linters use such lines to provide additional context (including stack traces) for the preceding diagnostic.
+func IsContinuationLine(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "note: ")
+}
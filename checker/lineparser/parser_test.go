@@ -0,0 +1,76 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// parseLines parses the provided lines using the provided parser and returns all of the issues that it returns.
+func parseLines(parser LineParser, lines ...string) []okgo.Issue {
+	var issues []okgo.Issue
+	for _, line := range lines {
+		issues = append(issues, parser.ParseLine(line)...)
+	}
+	return append(issues, parser.Flush()...)
+}
+
+func TestFolding(t *testing.T) {
+	parser := Folding(FromFunc(func(line, wd string) okgo.Issue {
+		if strings.HasPrefix(line, "# ") {
+			return okgo.Issue{}
+		}
+		return okgo.NewIssueFromLine(line, wd)
+	}), IsContinuationLine)("/wd")
+
+	got := parseLines(parser,
+		"\tcontinuation without a preceding issue",
+		"# example.com/foo",
+		"foo.go:3:2: cannot use x",
+		"\thave (int)",
+		"\twant (string)",
+		"note: module requires Go 1.30",
+		"bar.go:5:1: undefined: y",
+		"# example.com/bar",
+		"\tnot folded after an empty issue",
+	)
+	assert.Equal(t, []okgo.Issue{
+		{Content: "\tcontinuation without a preceding issue"},
+		{Path: "foo.go", Line: 3, Col: 2, Content: "cannot use x\n\thave (int)\n\twant (string)\nnote: module requires Go 1.30"},
+		{Path: "bar.go", Line: 5, Col: 1, Content: "undefined: y"},
+		{Content: "\tnot folded after an empty issue"},
+	}, got)
+}
+
+func TestFolding_MultipleIssuesPerLine(t *testing.T) {
+	parser := Folding(FromIssuesFunc(func(line, wd string) []okgo.Issue {
+		var issues []okgo.Issue
+		for _, content := range strings.Split(line, ";") {
+			issues = append(issues, okgo.Issue{Content: content})
+		}
+		return issues
+	}), IsContinuationLine)("/wd")
+
+	// continuation lines are folded into the last issue for the preceding line
+	assert.Equal(t, []okgo.Issue{
+		{Content: "a"},
+		{Content: "b\n\tcontext"},
+		{Content: "c"},
+	}, parseLines(parser, "a;b", "\tcontext", "c"))
+}
@@ -15,26 +15,47 @@
 package cmd
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
 	"strings"
+	"syscall"
 
 	"github.com/palantir/okgo/okgo"
 	"github.com/palantir/okgo/okgo/check"
+	"github.com/palantir/okgo/okgo/trace"
 	"github.com/palantir/pkg/matcher"
 	"github.com/palantir/pkg/pkgpath"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
 	checkCmd = &cobra.Command{
 		Use:   "check [flags] [checks]",
 		Short: "Run checks (runs all checks if none are specified)",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			projectParam, godelExcludeMatcher, err := okgoProjectParamFromFlags()
+		RunE: func(cmd *cobra.Command, args []string) (rErr error) {
+			if traceFlagVal != "" {
+				trace.Enable()
+				defer func() {
+					if err := trace.WriteFile(traceFlagVal); err != nil && rErr == nil {
+						rErr = err
+					}
+				}()
+			}
+
+			configSpan := trace.Begin(trace.MainLane, "config", "load configuration")
+			projectParam, godelExcludeMatcher, profileChecks, err := okgoProjectParamFromFlags(profileFlagVal)
+			configSpan.End()
 			if err != nil {
 				return err
 			}
@@ -42,21 +63,87 @@ var (
 			if parallelFlagVal {
 				parallelism = runtime.GOMAXPROCS(-1)
 			}
+			pkgsSpan := trace.Begin(trace.MainLane, "packages", "discover packages")
 			pkgs, err := pkgsInProject(projectDirFlagVal, godelExcludeMatcher)
+			pkgsSpan.SetArg("packages", len(pkgs))
+			pkgsSpan.End()
+			if err != nil {
+				return err
+			}
+			if len(args) == 0 {
+				// checks provided as arguments take precedence over the checks specified by the profile
+				for _, checkerType := range profileChecks {
+					args = append(args, string(checkerType))
+				}
+			}
+			checkerTypes, err := toCheckerTypes(args, projectParam, cliCheckerFactory, cliBuiltinCheckerTypes)
 			if err != nil {
 				return err
 			}
-			checkerTypes, err := toCheckerTypes(args, cliCheckerFactory)
+			checkerTypes, err = filterCheckerTypesByTags(checkerTypes, projectParam, cliCheckerFactory, tagFlagVal, excludeTagFlagVal)
 			if err != nil {
 				return err
 			}
-			return check.Run(projectParam, checkerTypes, pkgs, projectDirFlagVal, cliCheckerFactory, parallelism, cmd.OutOrStdout())
+			var durations check.Durations
+			var durationsFile string
+			if durationsCacheFlagVal {
+				durations, durationsFile = loadDurations(projectDirFlagVal)
+			}
+			// cancelling the checks on an interrupt kills the processes that they started, which run in their own process
+			// groups and would otherwise not receive the signal
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			var report check.Report
+			runErr := check.Run(projectParam, checkerTypes, pkgs, projectDirFlagVal, cliCheckerFactory, parallelism, cmd.OutOrStdout(),
+				check.RunParamContext(ctx),
+				check.RunParamGroupOutput(groupOutputFlagVal),
+				check.RunParamFailFast(failFastFlagVal),
+				check.RunParamDurations(durations),
+				check.RunParamMemoryCapacityMB(memoryCapacityMBFlagVal),
+				check.RunParamPrintSummary(summaryFlagVal),
+				check.RunParamReport(&report),
+			)
+			if durations != nil {
+				// durations are only used to optimize scheduling, so failing to save them is not an error
+				_ = durations.Save(durationsFile)
+			}
+			if reportJSONFlagVal != "" {
+				if err := report.Save(reportJSONFlagVal); err != nil {
+					return err
+				}
+			}
+			return runErr
 		},
 	}
 
-	parallelFlagVal bool
+	parallelFlagVal         bool
+	groupOutputFlagVal      bool
+	failFastFlagVal         bool
+	memoryCapacityMBFlagVal int
+	summaryFlagVal          bool
+	durationsCacheFlagVal   bool
+	reportJSONFlagVal       string
+	traceFlagVal            string
+	profileFlagVal          string
+	tagFlagVal              []string
+	excludeTagFlagVal       []string
 )
 
+const traceFlagName = "trace"
+
+// traceRequested returns true if the provided arguments for the check command specify a trace file. It is used to
+// enable tracing before the flags of the command are parsed, so only the trace flag is parsed and all other flags are
+// ignored.
+func traceRequested(args []string) bool {
+	flags := pflag.NewFlagSet(checkCmd.Name(), pflag.ContinueOnError)
+	flags.ParseErrorsAllowlist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	var traceFile string
+	flags.StringVar(&traceFile, traceFlagName, "", "")
+	_ = flags.Parse(args)
+	return traceFile != ""
+}
+
 func pkgsInProject(projectDir string, exclude matcher.Matcher) ([]string, error) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -65,13 +152,9 @@ func pkgsInProject(projectDir string, exclude matcher.Matcher) ([]string, error)
 	if !filepath.IsAbs(projectDir) {
 		projectDir = path.Join(wd, projectDir)
 	}
-	var relPathPrefix string
-	if wd != projectDir {
-		relPathPrefixVal, err := filepath.Rel(wd, projectDir)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to determine relative path")
-		}
-		relPathPrefix = relPathPrefixVal
+	relPathPrefix, err := projectDirRelPath(projectDir)
+	if err != nil {
+		return nil, err
 	}
 	pkgs, err := pkgpath.PackagesInDirMatchingRootModule(projectDir, exclude)
 	if err != nil {
@@ -94,10 +177,62 @@ func pkgsInProject(projectDir string, exclude matcher.Matcher) ([]string, error)
 	return pkgPaths, nil
 }
 
-func toCheckerTypes(in []string, factory okgo.CheckerFactory) ([]okgo.CheckerType, error) {
-	allCheckers := factory.Types()
+// loadDurations loads the durations of the previous runs of the checks for the provided project from the user cache
+// directory. Returns nil if the location of the durations file cannot be determined. Returns empty durations if the
+// durations file does not exist or cannot be read.
+func loadDurations(projectDir string) (check.Durations, string) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, ""
+	}
+	absProjectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil, ""
+	}
+	projectDirHash := sha256.Sum256([]byte(absProjectDir))
+	durationsFile := filepath.Join(cacheDir, "okgo", "durations", hex.EncodeToString(projectDirHash[:])+".json")
+	durations, err := check.LoadDurations(durationsFile)
+	if err != nil {
+		return check.Durations{}, durationsFile
+	}
+	return durations, durationsFile
+}
+
+// projectDirRelPath returns the path to the provided project directory relative to the working directory. Returns an
+// empty string if the project directory is the working directory.
+func projectDirRelPath(projectDir string) (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to determine working directory")
+	}
+	if !filepath.IsAbs(projectDir) {
+		projectDir = path.Join(wd, projectDir)
+	}
+	if wd == projectDir {
+		return "", nil
+	}
+	relPath, err := filepath.Rel(wd, projectDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to determine relative path")
+	}
+	return relPath, nil
+}
+
+// toCheckerTypes returns the checker types for the provided input. Valid checker types are the types provided by the
+// factory and the checks defined in the project parameters (which includes checks that are aliases). If the input is
+// empty, all valid checker types are returned except for built-in checkers that are not configured. builtinTypes is the
+// set of types provided by the factory that are built-in checkers.
+func toCheckerTypes(in []string, projectParam okgo.ProjectParam, factory okgo.CheckerFactory, builtinTypes map[okgo.CheckerType]struct{}) ([]okgo.CheckerType, error) {
+	allCheckers := allCheckerTypes(projectParam, factory)
 	if len(in) == 0 {
-		return allCheckers, nil
+		var out []okgo.CheckerType
+		for _, checkerType := range allCheckers {
+			if _, ok := builtinTypes[checkerType]; ok && !isConfigured(checkerType, projectParam) {
+				continue
+			}
+			out = append(out, checkerType)
+		}
+		return out, nil
 	}
 
 	checkerMap := make(map[string]okgo.CheckerType)
@@ -120,8 +255,83 @@ func toCheckerTypes(in []string, factory okgo.CheckerFactory) ([]okgo.CheckerTyp
 	return out, nil
 }
 
+func allCheckerTypes(projectParam okgo.ProjectParam, factory okgo.CheckerFactory) []okgo.CheckerType {
+	allCheckers := append([]okgo.CheckerType(nil), factory.Types()...)
+	for checkerType := range projectParam.Checks {
+		if !slices.Contains(allCheckers, checkerType) {
+			allCheckers = append(allCheckers, checkerType)
+		}
+	}
+	sort.Sort(okgo.ByCheckerType(allCheckers))
+	return allCheckers
+}
+
+// isConfigured returns true if the project parameters specify configuration for the provided check either at the top
+// level or for a directory.
+func isConfigured(checkerType okgo.CheckerType, projectParam okgo.ProjectParam) bool {
+	if _, ok := projectParam.Checks[checkerType]; ok {
+		return true
+	}
+	for _, dirParam := range projectParam.Directories {
+		if _, ok := dirParam.Checks[checkerType]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// filterCheckerTypesByTags returns the provided checker types filtered based on their tags. If tags is non-empty, only
+// the checkers that have at least one of the tags are retained. Checkers that have any of the excludeTags are removed.
+// The tags of a checker are the tags advertised by the checker along with the tags specified for it in configuration.
+func filterCheckerTypesByTags(in []okgo.CheckerType, projectParam okgo.ProjectParam, factory okgo.CheckerFactory, tags, excludeTags []string) ([]okgo.CheckerType, error) {
+	if len(tags) == 0 && len(excludeTags) == 0 {
+		return in, nil
+	}
+	var out []okgo.CheckerType
+	for _, checkerType := range in {
+		assetType := checkerType
+		if asset := projectParam.Checks[checkerType].Asset; asset != "" {
+			assetType = asset
+		}
+		checkerTags, err := okgo.CheckerTags(factory, assetType)
+		if err != nil {
+			return nil, err
+		}
+		checkerTags = append(append([]string(nil), checkerTags...), projectParam.Checks[checkerType].Tags...)
+		if len(tags) > 0 && !containsAny(checkerTags, tags) {
+			continue
+		}
+		if containsAny(checkerTags, excludeTags) {
+			continue
+		}
+		out = append(out, checkerType)
+	}
+	return out, nil
+}
+
+func containsAny(vals, want []string) bool {
+	for _, val := range vals {
+		for _, currWant := range want {
+			if val == currWant {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func init() {
 	checkCmd.Flags().BoolVar(&parallelFlagVal, "parallel", true, "run checks in parallel")
+	checkCmd.Flags().BoolVar(&groupOutputFlagVal, "group-output", true, "when running checks in parallel, print the output of each check as a contiguous block in priority order")
+	checkCmd.Flags().BoolVar(&failFastFlagVal, "fail-fast", false, "stop running checks as soon as a check produces output")
+	checkCmd.Flags().IntVar(&memoryCapacityMBFlagVal, "memory-capacity-mb", 0, "maximum estimated memory usage in megabytes of the checks that run at the same time (0 for no limit)")
+	checkCmd.Flags().BoolVar(&summaryFlagVal, "summary", true, "print a table with the status, number of issues and duration of each check after all of the checks have run (only if more than one check is run)")
+	checkCmd.Flags().BoolVar(&durationsCacheFlagVal, "durations-cache", false, "record the duration of each check in the user cache directory and start the checks that took the longest in the previous run first")
+	checkCmd.Flags().StringVar(&reportJSONFlagVal, "report-json", "", "path to a file to which a JSON report of the status, issues and duration of each check is written")
+	checkCmd.Flags().StringVar(&traceFlagVal, traceFlagName, "", "path to a file to which a Chrome trace-event timeline of the run is written")
+	checkCmd.Flags().StringSliceVar(&tagFlagVal, "tag", nil, "only run checks that have at least one of the specified tags")
+	checkCmd.Flags().StringSliceVar(&excludeTagFlagVal, "exclude-tag", nil, "do not run checks that have any of the specified tags")
+	checkCmd.Flags().StringVar(&profileFlagVal, "profile", "", "name of the profile (defined in the configuration) used to determine the checks to run and their configuration")
 
 	rootCmd.AddCommand(checkCmd)
 }
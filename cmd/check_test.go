@@ -0,0 +1,186 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/palantir/okgo/checker"
+	"github.com/palantir/okgo/checker/builtin"
+	"github.com/palantir/okgo/checker/checkerfactory"
+	"github.com/palantir/okgo/okgo"
+	"github.com/palantir/okgo/okgo/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tagsCheckerFactory struct {
+	okgo.CheckerFactory
+	tags map[okgo.CheckerType][]string
+}
+
+func (f *tagsCheckerFactory) Tags(checkerType okgo.CheckerType) ([]string, error) {
+	return f.tags[checkerType], nil
+}
+
+func TestFilterCheckerTypesByTags(t *testing.T) {
+	factory := &tagsCheckerFactory{
+		tags: map[okgo.CheckerType][]string{
+			"errcheck": {"correctness"},
+			"golint":   {"style"},
+			"unused":   {"correctness", "slow"},
+		},
+	}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			// tags specified in configuration are used in addition to the tags advertised by the checker
+			"golint": {Tags: []string{"slow"}},
+			// aliases use the tags advertised by the checker of their asset
+			"errcheck-tests": {Asset: "errcheck"},
+		},
+	}
+	checkerTypes := []okgo.CheckerType{"errcheck", "errcheck-tests", "golint", "unused"}
+
+	for _, tc := range []struct {
+		name        string
+		tags        []string
+		excludeTags []string
+		want        []okgo.CheckerType
+	}{
+		{
+			name: "no tags",
+			want: checkerTypes,
+		},
+		{
+			name: "tag",
+			tags: []string{"correctness"},
+			want: []okgo.CheckerType{"errcheck", "errcheck-tests", "unused"},
+		},
+		{
+			name: "multiple tags",
+			tags: []string{"style", "slow"},
+			want: []okgo.CheckerType{"golint", "unused"},
+		},
+		{
+			name:        "exclude tag",
+			excludeTags: []string{"slow"},
+			want:        []okgo.CheckerType{"errcheck", "errcheck-tests"},
+		},
+		{
+			name:        "tag and exclude tag",
+			tags:        []string{"correctness"},
+			excludeTags: []string{"slow"},
+			want:        []okgo.CheckerType{"errcheck", "errcheck-tests"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := filterCheckerTypesByTags(checkerTypes, projectParam, factory, tc.tags, tc.excludeTags)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFilterCheckerTypesByTags_FactoryWithoutTags(t *testing.T) {
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"golint": {Tags: []string{"style"}},
+		},
+	}
+	// only tags specified in configuration are used if the factory does not provide tags
+	var factory okgo.CheckerFactory = struct{ okgo.CheckerFactory }{}
+	got, err := filterCheckerTypesByTags([]okgo.CheckerType{"errcheck", "golint"}, projectParam, factory, []string{"style"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []okgo.CheckerType{"golint"}, got)
+}
+
+func TestToCheckerTypes_Builtins(t *testing.T) {
+	assetCreators := []checker.Creator{
+		checker.NewCreator("errcheck", 0, nil),
+		// asset that replaces the built-in checker of the same type
+		checker.NewCreator(builtin.ForbiddenPatternsCheckerType, 0, nil),
+	}
+	creators, _, builtinTypes := addBuiltinCheckers(assetCreators, nil)
+	var creatorTypes []okgo.CheckerType
+	for _, creator := range creators {
+		creatorTypes = append(creatorTypes, creator.Type())
+	}
+	factory := &typesCheckerFactory{types: creatorTypes}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			builtin.ImportPolicyCheckerType: {},
+		},
+	}
+
+	// built-in checkers are only run by default if they are configured
+	got, err := toCheckerTypes(nil, projectParam, factory, builtinTypes)
+	require.NoError(t, err)
+	assert.Equal(t, []okgo.CheckerType{"errcheck", builtin.ForbiddenPatternsCheckerType, builtin.ImportPolicyCheckerType}, got)
+}
+
+func TestCheck_UnconfiguredBuiltins(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module project\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "foo"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "foo", "foo.go"), []byte("package foo\n"), 0644))
+	t.Chdir(projectDir)
+
+	creators, upgraders, builtinTypes := addBuiltinCheckers(nil, nil)
+	factory, err := checkerfactory.New(creators, upgraders)
+	require.NoError(t, err)
+	projectParam, _, _, err := okgoProjectParamFromVals(projectDir, "", "", "", factory, builtinTypes)
+	require.NoError(t, err)
+
+	// built-in checkers are not run by default if they are not configured
+	checkerTypes, err := toCheckerTypes(nil, projectParam, factory, builtinTypes)
+	require.NoError(t, err)
+	assert.Empty(t, checkerTypes)
+
+	// built-in checkers that are not configured do nothing if they are run explicitly
+	var builtinTypeNames []string
+	for _, creator := range creators {
+		builtinTypeNames = append(builtinTypeNames, string(creator.Type()))
+	}
+	checkerTypes, err = toCheckerTypes(builtinTypeNames, projectParam, factory, builtinTypes)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, check.Run(projectParam, checkerTypes, []string{"./foo"}, projectDir, factory, 1, buf))
+	assert.Equal(t, `Running command...
+Finished command
+Running forbiddenpatterns...
+Finished forbiddenpatterns
+Running importpolicy...
+Finished importpolicy
+`, buf.String())
+}
+
+func TestTraceRequested(t *testing.T) {
+	for i, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"errcheck", "--parallel=false"}, false},
+		{[]string{"--trace", "trace.json"}, true},
+		{[]string{"--tag", "style", "errcheck", "--trace=trace.json"}, true},
+		{[]string{"--unknown", "--trace", "trace.json", "errcheck"}, true},
+		{[]string{"--", "--trace", "trace.json"}, false},
+	} {
+		assert.Equal(t, tc.want, traceRequested(tc.args), "Case %d", i)
+	}
+}
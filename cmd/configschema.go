@@ -0,0 +1,39 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/palantir/okgo/okgo/config"
+	"github.com/spf13/cobra"
+)
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "config-schema",
+	Short: "Print the JSON Schema for the configuration of the plugin and its checks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schema, err := config.JSONSchema(cliCheckerFactory)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(schema))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configSchemaCmd)
+}
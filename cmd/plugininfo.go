@@ -44,6 +44,11 @@ var (
 						"specifies whether or not checks are run in parallel (only used if 'check' task is run)",
 						godellauncher.BoolFlag,
 					),
+					pluginapi.NewVerifyFlag(
+						"profile",
+						"specifies the profile used to determine the checks that are run and their configuration (only used if 'check' task is run)",
+						godellauncher.StringFlag,
+					),
 				),
 				pluginapi.VerifyOptionsOrdering(intPtr(verifyorder.Check)),
 			),
@@ -53,6 +58,16 @@ var (
 			runCheckCmd.Short,
 			pluginapi.TaskInfoCommand(runCheckCmd.Name()),
 		),
+		pluginapi.PluginInfoTaskInfo(
+			validateConfigCmd.Name(),
+			validateConfigCmd.Short,
+			pluginapi.TaskInfoCommand(validateConfigCmd.Name()),
+		),
+		pluginapi.PluginInfoTaskInfo(
+			configSchemaCmd.Name(),
+			configSchemaCmd.Short,
+			pluginapi.TaskInfoCommand(configSchemaCmd.Name()),
+		),
 		pluginapi.PluginInfoUpgradeConfigTaskInfo(
 			pluginapi.UpgradeConfigTaskInfoCommand("upgrade-config"),
 			pluginapi.LegacyConfigFile("check.yml"),
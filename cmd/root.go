@@ -16,19 +16,23 @@ package cmd
 
 import (
 	"io/ioutil"
+	"path"
+	"path/filepath"
+	"slices"
 
 	godelconfig "github.com/palantir/godel/v2/framework/godel/config"
 	"github.com/palantir/godel/v2/framework/pluginapi"
 	"github.com/palantir/okgo/checker"
+	"github.com/palantir/okgo/checker/builtin"
 	"github.com/palantir/okgo/checker/checkerfactory"
 	"github.com/palantir/okgo/okgo"
 	"github.com/palantir/okgo/okgo/config"
+	"github.com/palantir/okgo/okgo/trace"
 	"github.com/palantir/pkg/cobracli"
 	"github.com/palantir/pkg/matcher"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"gopkg.in/yaml.v2"
 )
 
 var (
@@ -39,6 +43,8 @@ var (
 	assetsFlagVal          []string
 
 	cliCheckerFactory okgo.CheckerFactory
+	// cliBuiltinCheckerTypes is the set of checker types in cliCheckerFactory that are provided by built-in checkers.
+	cliBuiltinCheckerTypes map[okgo.CheckerType]struct{}
 )
 
 var rootCmd = &cobra.Command{
@@ -50,15 +56,21 @@ func Execute() int {
 }
 
 func InitAssetCmds(args []string) error {
-	if _, _, err := rootCmd.Traverse(args); err != nil && err != pflag.ErrHelp {
+	traversedCmd, cmdArgs, err := rootCmd.Traverse(args)
+	if err != nil && err != pflag.ErrHelp {
 		return errors.Wrapf(err, "failed to parse arguments")
 	}
+	if traversedCmd == checkCmd && traceRequested(cmdArgs) {
+		// enable tracing before loading the assets so that loading them is included in the trace
+		trace.Enable()
+	}
 
 	// load checker assets
 	checkerCreators, configUpgraders, err := checker.AssetCheckerCreators(assetsFlagVal...)
 	if err != nil {
 		return err
 	}
+	checkerCreators, configUpgraders, cliBuiltinCheckerTypes = addBuiltinCheckers(checkerCreators, configUpgraders)
 	cliCheckerFactory, err = checkerfactory.New(checkerCreators, configUpgraders)
 	if err != nil {
 		return err
@@ -70,6 +82,30 @@ func InitAssetCmds(args []string) error {
 	return nil
 }
 
+// addBuiltinCheckers returns the provided creators and upgraders along with those of the built-in checkers and the set
+// of types of the built-in checkers that were added. A built-in checker is not added if an asset provides a checker of
+// the same type.
+func addBuiltinCheckers(checkerCreators []checker.Creator, configUpgraders []okgo.ConfigUpgrader) ([]checker.Creator, []okgo.ConfigUpgrader, map[okgo.CheckerType]struct{}) {
+	assetTypes := make(map[okgo.CheckerType]struct{})
+	for _, creator := range checkerCreators {
+		assetTypes[creator.Type()] = struct{}{}
+	}
+	builtinTypes := make(map[okgo.CheckerType]struct{})
+	builtinCreators, builtinUpgraders := builtin.Creators()
+	for _, creator := range builtinCreators {
+		if _, ok := assetTypes[creator.Type()]; !ok {
+			checkerCreators = append(checkerCreators, creator)
+			builtinTypes[creator.Type()] = struct{}{}
+		}
+	}
+	for _, upgrader := range builtinUpgraders {
+		if _, ok := assetTypes[upgrader.TypeName()]; !ok {
+			configUpgraders = append(configUpgraders, upgrader)
+		}
+	}
+	return checkerCreators, configUpgraders, builtinTypes
+}
+
 func init() {
 	pluginapi.AddDebugPFlagPtr(rootCmd.PersistentFlags(), &debugFlagVal)
 	pluginapi.AddProjectDirPFlagPtr(rootCmd.PersistentFlags(), &projectDirFlagVal)
@@ -78,36 +114,106 @@ func init() {
 	pluginapi.AddAssetsPFlagPtr(rootCmd.PersistentFlags(), &assetsFlagVal)
 }
 
-func okgoProjectParamFromFlags() (okgo.ProjectParam, matcher.Matcher, error) {
-	return okgoProjectParamFromVals(okgoConfigFileFlagVal, godelConfigFileFlagVal, cliCheckerFactory)
+// okgoProjectParamFromFlags returns the project parameters based on the values of the global flags. If profile is
+// non-empty, the configuration for the specified profile is applied and the checks specified by the profile are
+// returned.
+func okgoProjectParamFromFlags(profile string) (okgo.ProjectParam, matcher.Matcher, []okgo.CheckerType, error) {
+	return okgoProjectParamFromVals(projectDirFlagVal, okgoConfigFileFlagVal, godelConfigFileFlagVal, profile, cliCheckerFactory, cliBuiltinCheckerTypes)
 }
 
-func okgoProjectParamFromVals(okgoConfigFile, godelConfigFile string, factory okgo.CheckerFactory) (okgo.ProjectParam, matcher.Matcher, error) {
+func okgoProjectParamFromVals(projectDir, okgoConfigFile, godelConfigFile, profile string, factory okgo.CheckerFactory, builtinTypes map[okgo.CheckerType]struct{}) (okgo.ProjectParam, matcher.Matcher, []okgo.CheckerType, error) {
 	var okgoCfg config.ProjectConfig
 	if okgoConfigFile != "" {
 		cfg, err := loadConfigFromFile(okgoConfigFile)
 		if err != nil {
-			return okgo.ProjectParam{}, nil, err
+			return okgo.ProjectParam{}, nil, nil, err
 		}
 		okgoCfg = cfg
 	}
+	var profileChecks []okgo.CheckerType
+	if profile != "" {
+		profileCfg, checks, err := okgoCfg.WithProfile(profile)
+		if err != nil {
+			return okgo.ProjectParam{}, nil, nil, err
+		}
+		okgoCfg = profileCfg
+		profileChecks = checks
+	}
 	var godelExcludes matcher.Matcher
 	if godelConfigFile != "" {
 		excludes, err := godelconfig.ReadGodelConfigExcludesFromFile(godelConfigFile)
 		if err != nil {
-			return okgo.ProjectParam{}, nil, err
+			return okgo.ProjectParam{}, nil, nil, err
 		}
 		godelExcludes = excludes.Matcher()
 		okgoCfg.Exclude.Add(excludes)
 	}
-	projectParam, err := okgoCfg.ToParam(factory)
+	dirCfgs, err := loadDirectoryConfigs(projectDir, godelExcludes)
+	if err != nil {
+		return okgo.ProjectParam{}, nil, nil, err
+	}
+	projectParam, err := okgoCfg.ToParamWithDirectoryConfigs(factory, dirCfgs)
 	if err != nil {
-		return okgo.ProjectParam{}, nil, err
+		return okgo.ProjectParam{}, nil, nil, err
 	}
+	removeUnconfiguredBuiltins(&projectParam, okgoCfg, dirCfgs, builtinTypes)
 	if godelExcludes == nil {
-		return projectParam, nil, nil
+		return projectParam, nil, profileChecks, nil
 	}
-	return projectParam, godelExcludes, nil
+	return projectParam, godelExcludes, profileChecks, nil
+}
+
+// removeUnconfiguredBuiltins removes the parameters for the built-in checkers that are not configured by the project
+// or by any of its directories. Built-in checkers that are not configured are not run by default, and run with an empty
+// configuration if they are requested explicitly.
+func removeUnconfiguredBuiltins(projectParam *okgo.ProjectParam, cfg config.ProjectConfig, dirCfgs []config.DirectoryConfig, builtinTypes map[okgo.CheckerType]struct{}) {
+	for checkerType := range builtinTypes {
+		if _, ok := cfg.Checks[checkerType]; ok {
+			continue
+		}
+		if slices.ContainsFunc(dirCfgs, func(dirCfg config.DirectoryConfig) bool {
+			_, ok := dirCfg.Config.Checks[checkerType]
+			return ok
+		}) {
+			continue
+		}
+		delete(projectParam.Checks, checkerType)
+		for _, dirParam := range projectParam.Directories {
+			delete(dirParam.Checks, checkerType)
+		}
+	}
+}
+
+// loadDirectoryConfigs loads all of the directory configuration files in the subdirectories of the provided project
+// directory. The directories of the returned configurations are relative to the working directory so that they can be
+// matched against the package paths provided to checks.
+func loadDirectoryConfigs(projectDir string, exclude matcher.Matcher) ([]config.DirectoryConfig, error) {
+	if projectDir == "" {
+		return nil, nil
+	}
+	cfgFiles, err := config.DirectoryConfigFiles(projectDir, exclude)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfgFiles) == 0 {
+		return nil, nil
+	}
+	relPathPrefix, err := projectDirRelPath(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	var dirCfgs []config.DirectoryConfig
+	for _, cfgFile := range cfgFiles {
+		cfg, err := loadConfigFromFile(filepath.Join(projectDir, cfgFile))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load configuration from %s", cfgFile)
+		}
+		dirCfgs = append(dirCfgs, config.DirectoryConfig{
+			Dir:    path.Join(relPathPrefix, path.Dir(cfgFile)),
+			Config: cfg,
+		})
+	}
+	return dirCfgs, nil
 }
 
 func loadConfigFromFile(cfgFile string) (config.ProjectConfig, error) {
@@ -121,9 +227,5 @@ func loadConfigFromFile(cfgFile string) (config.ProjectConfig, error) {
 		return config.ProjectConfig{}, err
 	}
 
-	var cfg config.ProjectConfig
-	if err := yaml.Unmarshal(upgradedCfg, &cfg); err != nil {
-		return config.ProjectConfig{}, errors.Wrapf(err, "failed to unmarshal configuration")
-	}
-	return cfg, nil
+	return config.UnmarshalProjectConfig(upgradedCfg)
 }
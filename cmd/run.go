@@ -15,15 +15,26 @@
 package cmd
 
 import (
+	"io"
+
 	"github.com/palantir/okgo/okgo"
+	"github.com/palantir/okgo/okgo/config"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 var (
 	runCheckCmd = &cobra.Command{
-		Use:   "run-check",
+		Use:   "run-check [check] [args]",
 		Short: "Runs a specific check",
+		// checks that are aliases are defined by the configuration rather than by assets, so they do not have their own
+		// subcommands and are resolved when the command is run
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			return runAliasCheckCmd(okgo.CheckerType(args[0]), args[1:], cmd.OutOrStdout())
+		},
 	}
 )
 
@@ -33,6 +44,10 @@ func init() {
 
 func addRunSubcommands() {
 	for _, checkerType := range cliCheckerFactory.Types() {
+		if _, ok := cliBuiltinCheckerTypes[checkerType]; ok {
+			// built-in checkers are defined by their configuration, so there is no underlying check to run directly
+			continue
+		}
 		runCheckCmd.AddCommand(createSingleRunCmd(checkerType, cliCheckerFactory))
 	}
 }
@@ -49,3 +64,41 @@ func createSingleRunCmd(checkerType okgo.CheckerType, factory okgo.CheckerFactor
 		},
 	}
 }
+
+// runAliasCheckCmd runs the check command of the asset for the provided check, which must be an alias defined in the
+// configuration, using the provided arguments. The check command does not use the configuration of the check, so
+// running the check command for an alias is the same as running it for its asset.
+func runAliasCheckCmd(alias okgo.CheckerType, args []string, stdout io.Writer) error {
+	var cfg config.ProjectConfig
+	if okgoConfigFileFlagVal != "" {
+		loadedCfg, err := loadConfigFromFile(okgoConfigFileFlagVal)
+		if err != nil {
+			return err
+		}
+		cfg = loadedCfg
+	}
+	assetType, err := aliasAssetType(cfg, alias, cliCheckerFactory, cliBuiltinCheckerTypes)
+	if err != nil {
+		return err
+	}
+	checker, err := cliCheckerFactory.NewChecker(assetType, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create checker for check %s", alias)
+	}
+	checker.RunCheckCmd(args, stdout)
+	return nil
+}
+
+// aliasAssetType returns the type of the asset for the provided check, which must be an alias defined in the provided
+// configuration for an asset that supports running its check command directly. builtinTypes is the set of types
+// provided by the factory that are built-in checkers.
+func aliasAssetType(cfg config.ProjectConfig, alias okgo.CheckerType, factory okgo.CheckerFactory, builtinTypes map[okgo.CheckerType]struct{}) (okgo.CheckerType, error) {
+	assetType := cfg.Checks[alias].Asset
+	if assetType == "" || assetType == alias {
+		return "", errors.Errorf("check %s is not a registered checker or an alias defined in the configuration (registered checkers: %v)", alias, factory.Types())
+	}
+	if _, ok := builtinTypes[assetType]; ok {
+		return "", errors.Errorf("check %s is an alias for the built-in checker %s, which does not have a check command that can be run directly", alias, assetType)
+	}
+	return assetType, nil
+}
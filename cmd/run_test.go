@@ -0,0 +1,64 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/palantir/okgo/okgo/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+type typesCheckerFactory struct {
+	okgo.CheckerFactory
+	types []okgo.CheckerType
+}
+
+func (f *typesCheckerFactory) Types() []okgo.CheckerType {
+	return f.types
+}
+
+func TestAliasAssetType(t *testing.T) {
+	var cfg config.ProjectConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+checks:
+  errcheck:
+    skip: true
+  errcheck-tests:
+    asset: errcheck
+  no-todos:
+    asset: forbiddenpatterns
+`), &cfg))
+	factory := &typesCheckerFactory{types: []okgo.CheckerType{"errcheck", "forbiddenpatterns"}}
+	builtinTypes := map[okgo.CheckerType]struct{}{"forbiddenpatterns": {}}
+
+	assetType, err := aliasAssetType(cfg, "errcheck-tests", factory, builtinTypes)
+	require.NoError(t, err)
+	assert.Equal(t, okgo.CheckerType("errcheck"), assetType)
+
+	_, err = aliasAssetType(cfg, "no-todos", factory, builtinTypes)
+	assert.EqualError(t, err, "check no-todos is an alias for the built-in checker forbiddenpatterns, which does not have a check command that can be run directly")
+
+	// an asset that replaces a built-in checker has a check command that can be run
+	assetType, err = aliasAssetType(cfg, "no-todos", factory, nil)
+	require.NoError(t, err)
+	assert.Equal(t, okgo.CheckerType("forbiddenpatterns"), assetType)
+
+	_, err = aliasAssetType(cfg, "unknown", factory, builtinTypes)
+	assert.EqualError(t, err, "check unknown is not a registered checker or an alias defined in the configuration (registered checkers: [errcheck forbiddenpatterns])")
+}
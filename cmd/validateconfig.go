@@ -0,0 +1,94 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	godelconfig "github.com/palantir/godel/v2/framework/godel/config"
+	"github.com/palantir/okgo/okgo"
+	"github.com/palantir/okgo/okgo/config"
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var validateConfigCmd = &cobra.Command{
+	Use:   "validate-config",
+	Short: "Validate the configuration for the plugin and its checks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var cfgFiles []string
+		if okgoConfigFileFlagVal != "" {
+			cfgFiles = append(cfgFiles, okgoConfigFileFlagVal)
+		}
+		var godelExcludes matcher.Matcher
+		if godelConfigFileFlagVal != "" {
+			excludes, err := godelconfig.ReadGodelConfigExcludesFromFile(godelConfigFileFlagVal)
+			if err != nil {
+				return err
+			}
+			godelExcludes = excludes.Matcher()
+		}
+		if projectDirFlagVal != "" {
+			dirCfgFiles, err := config.DirectoryConfigFiles(projectDirFlagVal, godelExcludes)
+			if err != nil {
+				return err
+			}
+			for _, dirCfgFile := range dirCfgFiles {
+				cfgFiles = append(cfgFiles, filepath.Join(projectDirFlagVal, dirCfgFile))
+			}
+		}
+
+		var issues []okgo.Issue
+		for _, cfgFile := range cfgFiles {
+			cfgBytes, err := os.ReadFile(cfgFile)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read configuration file")
+			}
+			issues = append(issues, config.Validate(displayPath(cfgFile), cfgBytes, cliCheckerFactory)...)
+		}
+		if len(issues) == 0 {
+			return nil
+		}
+		for _, issue := range issues {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), issue.String())
+		}
+		// return empty failure to indicate non-zero exit code
+		return fmt.Errorf("")
+	},
+}
+
+// displayPath returns the provided path relative to the working directory if possible. Otherwise, returns the provided
+// path unmodified.
+func displayPath(in string) string {
+	if !filepath.IsAbs(in) {
+		return in
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return in
+	}
+	relPath, err := filepath.Rel(wd, in)
+	if err != nil {
+		return in
+	}
+	return relPath
+}
+
+func init() {
+	rootCmd.AddCommand(validateConfigCmd)
+}
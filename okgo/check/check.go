@@ -15,71 +15,249 @@
 package check
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/palantir/okgo/okgo"
+	"github.com/palantir/okgo/okgo/trace"
+	"github.com/palantir/pkg/matcher"
 	"github.com/pkg/errors"
 )
 
-func Run(projectParam okgo.ProjectParam, checkersToRun []okgo.CheckerType, pkgPaths []string, projectDir string, factory okgo.CheckerFactory, parallelism int, stdout io.Writer) error {
-	checkers, maxTypeLen, err := getCheckersToRun(projectParam, checkersToRun, factory)
+type runParams struct {
+	ctx              context.Context
+	groupOutput      bool
+	failFast         bool
+	durations        Durations
+	memoryCapacityMB int
+	printSummary     bool
+	report           *Report
+}
+
+type RunParam interface {
+	apply(p *runParams)
+}
+
+type runParamFunc func(*runParams)
+
+func (f runParamFunc) apply(p *runParams) {
+	f(p)
+}
+
+// RunParamGroupOutput specifies whether the output of each check is written as a contiguous block when checks are run
+// in parallel. If true, the output of each check is buffered until the check completes, the issues reported by the
+// check are sorted by path and position, and the blocks of output are written in the order in which the checks are
+// prioritized (which may mean waiting for higher-priority checks to complete before writing the output of a check).
+// This ensures that the output is the same across runs regardless of the order in which the checks complete.
+func RunParamGroupOutput(groupOutput bool) RunParam {
+	return runParamFunc(func(p *runParams) {
+		p.groupOutput = groupOutput
+	})
+}
+
+// RunParamFailFast specifies whether to stop running checks as soon as a check produces output. If true, once a check
+// produces output, checks that have not yet started are not run and checks that are running are cancelled.
+func RunParamFailFast(failFast bool) RunParam {
+	return runParamFunc(func(p *runParams) {
+		p.failFast = failFast
+	})
+}
+
+// RunParamDurations specifies the durations of previous runs of the checks. Among checks with the same priority, the
+// checks that took the longest are started first (checks without a recorded duration are started before all others).
+// The durations of the checks that complete during the run are recorded in the provided value.
+func RunParamDurations(durations Durations) RunParam {
+	return runParamFunc(func(p *runParams) {
+		p.durations = durations
+	})
+}
+
+// RunParamMemoryCapacityMB specifies the amount of memory in megabytes that can be used by the checks that run at the
+// same time. A check is not started while running it would cause the estimated memory usage of the running checks to
+// exceed the capacity (unless no other check is running). If 0, memory usage is not considered.
+func RunParamMemoryCapacityMB(memoryCapacityMB int) RunParam {
+	return runParamFunc(func(p *runParams) {
+		p.memoryCapacityMB = memoryCapacityMB
+	})
+}
+
+// RunParamContext specifies the context of the run. If the context is done, the running checks are cancelled and the
+// checks that have not started are not run.
+func RunParamContext(ctx context.Context) RunParam {
+	return runParamFunc(func(p *runParams) {
+		p.ctx = ctx
+	})
+}
+
+// RunParamPrintSummary specifies whether a table that summarizes the status, number of issues and duration of each
+// check is written after all of the checks have run. The table is only written if more than one check is run.
+func RunParamPrintSummary(printSummary bool) RunParam {
+	return runParamFunc(func(p *runParams) {
+		p.printSummary = printSummary
+	})
+}
+
+// RunParamReport specifies a report that is populated with the results of the checks (including the issues that they
+// report) once the run completes.
+func RunParamReport(report *Report) RunParam {
+	return runParamFunc(func(p *runParams) {
+		p.report = report
+	})
+}
+
+// Run runs the specified checks. At most parallelism checks are run at the same time, and parallelism is also the
+// number of CPUs that can be used by the checks that run at the same time.
+func Run(projectParam okgo.ProjectParam, checkersToRun []okgo.CheckerType, pkgPaths []string, projectDir string, factory okgo.CheckerFactory, parallelism int, stdout io.Writer, params ...RunParam) error {
+	var runParams runParams
+	for _, p := range params {
+		if p == nil {
+			continue
+		}
+		p.apply(&runParams)
+	}
+
+	checkers, maxTypeLen, err := getCheckersToRun(projectParam, checkersToRun, factory, runParams.durations)
 	if err != nil {
 		return err
 	}
+	capacity := okgo.CheckerResources{
+		CPU:      parallelism,
+		MemoryMB: runParams.memoryCapacityMB,
+	}
 	// if there are fewer checkers than max parallelism, update parallelism to number of checkers
 	if len(checkers) < parallelism {
 		parallelism = len(checkers)
 	}
 
-	jobs := make(chan okgo.CheckerParam, len(checkers))
-	results := make(chan checkResult, len(checkers))
+	for i := range checkers {
+		checkers[i].shards = len(shardPkgPaths(pkgPaths, checkers[i].Shards, checkers[i].ShardSize))
+	}
+	s, err := newScheduler(checkers, parallelism, capacity)
+	if err != nil {
+		return err
+	}
+	multipleWorkers := parallelism > 1
+	out := newRunOutput(stdout, len(checkers), runParams.groupOutput && multipleWorkers)
+
+	parentCtx := runParams.ctx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	// the run cache is only referenced by the context of the run, so the values stored in it are released once the run
+	// completes
+	ctx, cancel := context.WithCancel(okgo.ContextWithRunCache(parentCtx, okgo.NewRunCache()))
+	defer cancel()
+
+	summaries := make([]CheckSummary, len(checkers))
+	for i, checker := range checkers {
+		summaries[i] = CheckSummary{
+			Check:  checker.checkerType,
+			Status: CheckStatusSkipped,
+		}
+	}
 
-	var checksWithFailures []string
-	pullResultsOff := func(toRun int) {
-		for i := 0; i < toRun; i++ {
-			checkResult := <-results
-			if checkResult.producedOutput {
-				checksWithFailures = append(checksWithFailures, string(checkResult.checkerType))
+	// lanes are the timeline lanes of the workers that run the checks. A check is run on the first lane that is not in
+	// use by another check.
+	lanes := make([]int, 0, parallelism)
+	jobLanes := make([]int, len(checkers))
+	laneInUse := make(map[int]bool)
+	acquireLane := func() int {
+		for _, lane := range lanes {
+			if !laneInUse[lane] {
+				laneInUse[lane] = true
+				return lane
 			}
 		}
+		lane := trace.NewLane(fmt.Sprintf("check worker %d", len(lanes)+1))
+		lanes = append(lanes, lane)
+		laneInUse[lane] = true
+		return lane
 	}
 
-	startASingleWorker := func() {
-		go singleCheckWorker(pkgPaths, projectDir, maxTypeLen, parallelism > 1, jobs, results, stdout)
+	results := make(chan checkResult, len(checkers))
+	var (
+		checksWithFailures []string
+		checksNotRun       []string
+	)
+	for !s.done() {
+		for _, idx := range s.next() {
+			jobLanes[idx] = acquireLane()
+			go func(idx int) {
+				span := trace.Begin(jobLanes[idx], "check", string(checkers[idx].checkerType))
+				start := time.Now()
+				result := getCheckResultFromChecker(ctx, pkgPaths, projectDir, maxTypeLen, multipleWorkers, checkers[idx], s.concurrentShards[idx], jobOutput{
+					stdout:       out.jobWriter(idx),
+					sortIssues:   out.grouped,
+					recordIssues: runParams.report != nil,
+				})
+				result.jobIdx = idx
+				result.duration = time.Since(start)
+				span.SetArg("status", result.status)
+				span.SetArg("issues", result.issueCount)
+				span.End()
+				results <- result
+			}(idx)
+		}
+		result := <-results
+		laneInUse[jobLanes[result.jobIdx]] = false
+		out.jobDone(result.jobIdx)
+		summaries[result.jobIdx].Status = result.status
+		summaries[result.jobIdx].IssueCount = result.issueCount
+		summaries[result.jobIdx].Duration = result.duration
+		summaries[result.jobIdx].Issues = result.issues
+		if runParams.durations != nil && result.checkerType != "" && ctx.Err() == nil {
+			runParams.durations[result.checkerType] = result.duration
+		}
+		if result.producedOutput {
+			checksWithFailures = append(checksWithFailures, string(result.checkerType))
+		}
+		for _, skippedIdx := range s.finish(result.jobIdx, !result.producedOutput) {
+			skippedType := checkers[skippedIdx].checkerType
+			_, _ = fmt.Fprintf(out.jobWriter(skippedIdx), "%sSkipping %s because check(s) it depends on did not pass: %v\n",
+				outputPrefix(skippedType, maxTypeLen, multipleWorkers), skippedType, s.failedDependencies(skippedIdx))
+			out.jobDone(skippedIdx)
+		}
+		if result.producedOutput && runParams.failFast && ctx.Err() == nil {
+			// stop running checks: cancel the running checks and do not start any more
+			cancel()
+			for _, notRunIdx := range s.cancel() {
+				checksNotRun = append(checksNotRun, string(checkers[notRunIdx].checkerType))
+				summaries[notRunIdx].Status = CheckStatusCancelled
+				out.jobDone(notRunIdx)
+			}
+		}
+		if parentCtx.Err() != nil {
+			// the run was cancelled: the running checks are cancelled by the context, so do not start any more
+			for _, notRunIdx := range s.cancel() {
+				summaries[notRunIdx].Status = CheckStatusCancelled
+				out.jobDone(notRunIdx)
+			}
+		}
 	}
-	// Always start 1 worker no matter what
-	startASingleWorker()
 
-	// Bucket checks into serial and parallel ones and run all serial ones first
-	checkersToRunSerially, checkersToRunInParallel, err := partitionCheckerJobs(checkers)
-	if err != nil {
-		return err
+	if runParams.report != nil {
+		runParams.report.Checks = summaries
 	}
-	// Start all jobs that must be run serially. This enqueues all jobs in order and
-	// because there is only one worker they will run one at a time.
-	for _, checker := range checkersToRunSerially {
-		jobs <- checker
+	if runParams.printSummary && len(summaries) > 1 {
+		writeSummaryTable(stdout, summaries)
 	}
-	// Retrieve all results
-	pullResultsOff(len(checkersToRunSerially))
 
-	// Finished processing serial checks: start up the rest of the workers to enable
-	// maximal supported parallelism for workers
-	for w := 0; w < parallelism-1; w++ {
-		startASingleWorker()
+	if len(checksNotRun) > 0 {
+		sort.Strings(checksNotRun)
+		_, _ = fmt.Fprintln(stdout, "Check(s) not run because a check produced output:", checksNotRun)
 	}
-	// Enqueue the checks that can run in parallel
-	for _, checker := range checkersToRunInParallel {
-		jobs <- checker
+	if err := parentCtx.Err(); err != nil {
+		return errors.Wrapf(err, "run was cancelled")
 	}
-	// Retrieve the rest of the results
-	pullResultsOff(len(checkersToRunInParallel))
-
 	if len(checksWithFailures) > 0 {
 		sort.Strings(checksWithFailures)
 		_, _ = fmt.Fprintln(stdout, "Check(s) produced output:", checksWithFailures)
@@ -89,8 +267,28 @@ func Run(projectParam okgo.ProjectParam, checkersToRun []okgo.CheckerType, pkgPa
 	return nil
 }
 
-func getCheckersToRun(projectParam okgo.ProjectParam, checkersToRun []okgo.CheckerType, factory okgo.CheckerFactory) ([]okgo.CheckerParam, int, error) {
-	var checkers []okgo.CheckerParam
+// checkJob is a check that should be run along with the directory-specific parameters for the check.
+type checkJob struct {
+	okgo.CheckerParam
+
+	// checkerType is the type of the check.
+	checkerType okgo.CheckerType
+
+	// dirParams are the directory-specific parameters for the check.
+	dirParams []dirCheckerParam
+
+	// shards is the number of concurrent invocations of the check when it is run on all of the packages using its
+	// top-level parameters. It is used to estimate the resources used by the check.
+	shards int
+}
+
+type dirCheckerParam struct {
+	dir   string
+	param okgo.CheckerParam
+}
+
+func getCheckersToRun(projectParam okgo.ProjectParam, checkersToRun []okgo.CheckerType, factory okgo.CheckerFactory, durations Durations) ([]checkJob, int, error) {
+	var checkers []checkJob
 	maxTypeLen := 0
 	for _, checkerType := range checkersToRun {
 		if len(checkerType) > maxTypeLen {
@@ -98,45 +296,144 @@ func getCheckersToRun(projectParam okgo.ProjectParam, checkersToRun []okgo.Check
 		}
 		param, ok := projectParam.Checks[checkerType]
 		if ok {
-			checkers = append(checkers, param)
+			checkers = append(checkers, checkJob{
+				CheckerParam: param,
+				checkerType:  checkerType,
+				dirParams:    getDirCheckerParams(projectParam, checkerType),
+			})
 			continue
 		}
 		checker, err := factory.NewChecker(checkerType, nil)
 		if err != nil {
 			return nil, 0, errors.Wrapf(err, "failed to create checkerType %s", checkerType)
 		}
-		checkers = append(checkers, okgo.CheckerParam{
-			Checker: checker,
+		checkers = append(checkers, checkJob{
+			CheckerParam: okgo.CheckerParam{
+				Checker: checker,
+			},
+			checkerType: checkerType,
 		})
 	}
 
 	// sort the checkers
-	if err := sortCheckers(checkers); err != nil {
+	if err := sortCheckers(checkers, durations); err != nil {
 		return nil, 0, err
 	}
 	return checkers, maxTypeLen, nil
 }
 
-func partitionCheckerJobs(checkers []okgo.CheckerParam) ([]okgo.CheckerParam, []okgo.CheckerParam, error) {
-	var (
-		multiCPUCheckers  []okgo.CheckerParam
-		singleCPUCheckers []okgo.CheckerParam
-	)
-	for _, checker := range checkers {
-		multiCPU, err := checker.Checker.MultiCPU()
-		if err != nil {
-			return nil, nil, err
+func getDirCheckerParams(projectParam okgo.ProjectParam, checkerType okgo.CheckerType) []dirCheckerParam {
+	var dirParams []dirCheckerParam
+	for _, dir := range projectParam.Directories {
+		param, ok := dir.Checks[checkerType]
+		if !ok {
+			continue
 		}
-		if multiCPU {
-			multiCPUCheckers = append(multiCPUCheckers, checker)
-		} else {
-			singleCPUCheckers = append(singleCPUCheckers, checker)
+		dirParams = append(dirParams, dirCheckerParam{
+			dir:   path.Clean(dir.Dir),
+			param: param,
+		})
+	}
+	return dirParams
+}
+
+// dependsOn returns the checks that the job depends on. A job depends on all of the checks that any of its parameters
+// depend on.
+func (j checkJob) dependsOn() []okgo.CheckerType {
+	dependsOn := append([]okgo.CheckerType(nil), j.DependsOn...)
+	for _, dirParam := range j.dirParams {
+		for _, dependency := range dirParam.param.DependsOn {
+			if !slices.Contains(dependsOn, dependency) {
+				dependsOn = append(dependsOn, dependency)
+			}
+		}
+	}
+	return dependsOn
+}
+
+// checkGroup is a set of packages that are checked by a single invocation of a checker using the same parameters.
+type checkGroup struct {
+	param    okgo.CheckerParam
+	pkgPaths []string
+}
+
+// groupPkgPaths partitions the provided packages based on the parameters that apply to them. Every package is checked
+// using the parameters for the most specific directory that contains it, or using the top-level parameters if it is
+// not in any such directory. Packages whose parameters are the same are checked in the same group. The group that uses
+// the top-level parameters is always returned first (even if it is empty), followed by the directory groups in the
+// order in which their first package was provided.
+func (j checkJob) groupPkgPaths(pkgPaths []string) []checkGroup {
+	groups := []checkGroup{{
+		param: j.CheckerParam,
+	}}
+	dirGroupIdx := make(map[int]int)
+	for _, pkgPath := range pkgPaths {
+		dirParamIdx := j.dirParamIdxForPath(pkgPath)
+		if dirParamIdx == -1 {
+			groups[0].pkgPaths = append(groups[0].pkgPaths, pkgPath)
+			continue
+		}
+		groupIdx, ok := dirGroupIdx[dirParamIdx]
+		if !ok {
+			groupIdx = groupIdxForParam(groups, j.dirParams[dirParamIdx].param)
+			if groupIdx == -1 {
+				groupIdx = len(groups)
+				groups = append(groups, checkGroup{
+					param: j.dirParams[dirParamIdx].param,
+				})
+			}
+			dirGroupIdx[dirParamIdx] = groupIdx
 		}
+		groups[groupIdx].pkgPaths = append(groups[groupIdx].pkgPaths, pkgPath)
 	}
-	return multiCPUCheckers, singleCPUCheckers, nil
+	return groups
 }
 
-func sortCheckers(checkers []okgo.CheckerParam) error {
+// groupIdxForParam returns the index of the group that uses the same parameters as the provided parameters. Returns -1
+// if there is no such group.
+func groupIdxForParam(groups []checkGroup, param okgo.CheckerParam) int {
+	for i, group := range groups {
+		if sameCheckerParam(group.param, param) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameCheckerParam returns true if the provided parameters are the same. Parameters are only the same if they use the
+// same checker instance (checkers of a comparable type are compared by identity) and all of their other fields are
+// deeply equal.
+func sameCheckerParam(a, b okgo.CheckerParam) bool {
+	if a.Checker == nil || b.Checker == nil {
+		if a.Checker != nil || b.Checker != nil {
+			return false
+		}
+	} else if reflect.TypeOf(a.Checker) != reflect.TypeOf(b.Checker) || !reflect.TypeOf(a.Checker).Comparable() || a.Checker != b.Checker {
+		return false
+	}
+	a.Checker, b.Checker = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+// dirParamIdxForPath returns the index of the directory parameters for the most specific directory that contains the
+// provided path. Returns -1 if the path is not in any of the directories.
+func (j checkJob) dirParamIdxForPath(pkgPath string) int {
+	pkgPath = path.Clean(pkgPath)
+	matchIdx := -1
+	for i, dirParam := range j.dirParams {
+		if pkgPath != dirParam.dir && !strings.HasPrefix(pkgPath, dirParam.dir+"/") {
+			continue
+		}
+		if matchIdx == -1 || len(dirParam.dir) > len(j.dirParams[matchIdx].dir) {
+			matchIdx = i
+		}
+	}
+	return matchIdx
+}
+
+// sortCheckers sorts the provided checkers by priority. Checkers with the same priority are sorted by their duration in
+// descending order (checkers without a duration are sorted first) and then alphabetically.
+func sortCheckers(checkers []checkJob, durations Durations) error {
 	var rErr error
 	sort.Slice(checkers, func(i, j int) bool {
 		var iPriority okgo.CheckerPriority
@@ -162,7 +459,17 @@ func sortCheckers(checkers []okgo.CheckerParam) error {
 		}
 
 		if iPriority == jPriority {
-			// if priority is the same, sort alphabetically
+			// if priority is the same, start the checks that take the longest first
+			iDuration, iOK := durations[checkers[i].checkerType]
+			jDuration, jOK := durations[checkers[j].checkerType]
+			if iOK != jOK {
+				return !iOK
+			}
+			if iDuration != jDuration {
+				return iDuration > jDuration
+			}
+
+			// if duration is also the same, sort alphabetically
 			iType, err := checkers[i].Checker.Type()
 			if err != nil && rErr == nil {
 				rErr = err
@@ -182,96 +489,234 @@ func sortCheckers(checkers []okgo.CheckerParam) error {
 }
 
 type checkResult struct {
+	// jobIdx is the index of the job that produced the result.
+	jobIdx         int
 	checkerType    okgo.CheckerType
 	producedOutput bool
-}
-
-func singleCheckWorker(pkgPaths []string, projectDir string, maxTypeLen int, multipleWorkers bool, checkJobs <-chan okgo.CheckerParam, results chan<- checkResult, stdout io.Writer) {
-	for checkerParam := range checkJobs {
-		results <- getCheckResultFromChecker(pkgPaths, projectDir, maxTypeLen, multipleWorkers, checkerParam, stdout)
-	}
+	duration       time.Duration
+	status         CheckStatus
+	issueCount     int
+	issues         []okgo.Issue
 }
 
 func getCheckResultFromChecker(
+	ctx context.Context,
 	pkgPaths []string,
 	projectDir string,
 	maxTypeLen int,
 	multipleWorkers bool,
-	checkerParam okgo.CheckerParam,
-	stdout io.Writer) checkResult {
-	if checkerParam.Skip {
-		return checkResult{}
+	job checkJob,
+	concurrentShards int,
+	output jobOutput) checkResult {
+	groups := job.groupPkgPaths(pkgPaths)
+	var groupsToRun []checkGroup
+	for i, group := range groups {
+		if group.param.Skip {
+			continue
+		}
+		if len(groups) > 1 && len(group.pkgPaths) == 0 {
+			// if packages were partitioned into multiple groups, do not run the check for groups without packages
+			continue
+		}
+		groupsToRun = append(groupsToRun, groups[i])
+	}
+	if len(groupsToRun) == 0 {
+		return checkResult{
+			status: CheckStatusSkipped,
+		}
 	}
-	checkerType, err := checkerParam.Checker.Type()
+	checkerType, err := job.Checker.Type()
 	if err != nil {
-		_, _ = fmt.Fprintf(stdout, "failed to determine type for Checker: %v", err)
+		_, _ = fmt.Fprintf(output.stdout, "failed to determine type for Checker: %v", err)
 		return checkResult{
 			checkerType:    "UNKNOWN_CHECK_TYPE",
 			producedOutput: true,
+			status:         CheckStatusError,
 		}
 	}
-	prefixWithPadding := ""
-	if multipleWorkers {
-		prefixWithPadding = fmt.Sprintf("[%s] ", checkerType) + strings.Repeat(" ", maxTypeLen-len(checkerType))
+	return runCheck(ctx, checkerType, outputPrefix(checkerType, maxTypeLen, multipleWorkers), groupsToRun, projectDir, concurrentShards, output)
+}
+
+// jobOutput specifies how the output of a job is written.
+type jobOutput struct {
+	stdout io.Writer
+	// sortIssues specifies whether issues are written in sorted order after the check completes rather than as they are
+	// reported.
+	sortIssues bool
+	// recordIssues specifies whether the issues are returned in the result of the check.
+	recordIssues bool
+}
+
+// outputPrefix returns the prefix for the output lines for the specified check. Output is only prefixed if checks are
+// run by multiple workers.
+func outputPrefix(checkerType okgo.CheckerType, maxTypeLen int, multipleWorkers bool) string {
+	if !multipleWorkers {
+		return ""
 	}
-	return runCheck(checkerType, prefixWithPadding, checkerParam, pkgPaths, projectDir, stdout)
+	return fmt.Sprintf("[%s] ", checkerType) + strings.Repeat(" ", maxTypeLen-len(checkerType))
 }
 
-func runCheck(checkerType okgo.CheckerType, outputPrefix string, checkerParam okgo.CheckerParam, pkgPaths []string, projectDir string, stdout io.Writer) checkResult {
+// runCheck runs the check for the provided groups of packages and writes its output as specified by the provided
+// jobOutput. At most concurrentShards shards of a sharded group are checked at the same time.
+func runCheck(ctx context.Context, checkerType okgo.CheckerType, outputPrefix string, groups []checkGroup, projectDir string, concurrentShards int, output jobOutput) checkResult {
+	stdout := output.stdout
 	_, _ = fmt.Fprintf(stdout, "%sRunning %s...\n", outputPrefix, checkerType)
 
 	result := checkResult{
 		checkerType: checkerType,
 	}
+	issues := &issueWriter{
+		stdout:   stdout,
+		prefix:   outputPrefix,
+		buffered: output.sortIssues,
+		record:   output.recordIssues,
+	}
+	for _, group := range groups {
+		if ctx.Err() != nil {
+			break
+		}
+		if runCheckGroup(ctx, issues, group.param, group.pkgPaths, projectDir, concurrentShards) {
+			result.producedOutput = true
+		}
+	}
+	issues.flush()
+	result.issueCount = issues.count
+	result.issues = issues.recorded
+
+	switch {
+	case ctx.Err() != nil:
+		result.status = CheckStatusCancelled
+	case issues.errored:
+		result.status = CheckStatusError
+	case result.producedOutput:
+		result.status = CheckStatusFail
+	default:
+		result.status = CheckStatusPass
+	}
+	if ctx.Err() != nil {
+		_, _ = fmt.Fprintf(stdout, "%sCancelled %s\n", outputPrefix, checkerType)
+		return result
+	}
+	_, _ = fmt.Fprintf(stdout, "%sFinished %s\n", outputPrefix, checkerType)
+
+	return result
+}
+
+// runCheckGroup runs the check specified by checkerParam on the provided packages and writes the issues that it
+// produces to stdout. If the parameters specify sharding, the packages are split into shards that are checked
+// concurrently, with at most concurrentShards shards being checked at the same time. Returns true if any output was
+// produced.
+func runCheckGroup(ctx context.Context, issues *issueWriter, checkerParam okgo.CheckerParam, pkgPaths []string, projectDir string, concurrentShards int) bool {
 	filteredPkgPaths := getFilteredPkgPaths(checkerParam, pkgPaths)
+	shards := shardPkgPaths(filteredPkgPaths, checkerParam.Shards, checkerParam.ShardSize)
+	if len(shards) == 1 {
+		return runCheckShard(ctx, issues, checkerParam, shards[0], projectDir)
+	}
+
+	var wg sync.WaitGroup
+	// sem limits the number of shards that are checked at the same time to the number for which CPUs were reserved
+	sem := make(chan struct{}, max(concurrentShards, 1))
+	shardProducedOutput := make([]bool, len(shards))
+	for i, shard := range shards {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, shard []string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			shardProducedOutput[i] = runCheckShard(ctx, issues, checkerParam, shard, projectDir)
+		}(i, shard)
+	}
+	wg.Wait()
+	for _, producedOutput := range shardProducedOutput {
+		if producedOutput {
+			return true
+		}
+	}
+	return false
+}
+
+// shardPkgPaths splits the provided packages into contiguous shards of similar size. If shardSize is positive, the
+// packages are split into as many shards as necessary so that no shard has more than shardSize packages. Otherwise, if
+// shards is positive, the packages are split into that many shards (or one shard per package if there are fewer
+// packages). Always returns at least one shard.
+func shardPkgPaths(pkgPaths []string, shards, shardSize int) [][]string {
+	numShards := 1
+	switch {
+	case shardSize > 0:
+		numShards = (len(pkgPaths) + shardSize - 1) / shardSize
+	case shards > 0:
+		numShards = shards
+	}
+	if numShards > len(pkgPaths) {
+		numShards = len(pkgPaths)
+	}
+	if numShards <= 1 {
+		return [][]string{pkgPaths}
+	}
+	out := make([][]string, 0, numShards)
+	start := 0
+	for i := 0; i < numShards; i++ {
+		// distribute the remainder across the first shards
+		end := start + len(pkgPaths)/numShards
+		if i < len(pkgPaths)%numShards {
+			end++
+		}
+		out = append(out, pkgPaths[start:end])
+		start = end
+	}
+	return out
+}
+
+// runCheckShard runs the check specified by checkerParam on the provided packages and writes the issues that it
+// produces to the provided issueWriter. Returns true if any output was produced.
+func runCheckShard(ctx context.Context, issues *issueWriter, checkerParam okgo.CheckerParam, pkgPaths []string, projectDir string) (producedOutput bool) {
 	pipeR, pipeW, err := os.Pipe()
 	if err != nil {
-		_, _ = fmt.Fprintf(stdout, "%s%s\n", outputPrefix, "failed to create pipe")
-		result.producedOutput = true
-		return result
+		issues.writeError("failed to create pipe")
+		return true
 	}
 
 	done := make(chan bool)
 
 	go func() {
-		scanner := bufio.NewScanner(pipeR)
-		for scanner.Scan() {
-			line := scanner.Text()
+		if err := okgo.ReadLines(pipeR, func(line string) {
 			issue := okgo.NewIssueFromJSON(line)
 			if shouldSkipIssue(issue, checkerParam) {
-				continue
+				return
 			}
-			_, _ = fmt.Fprintf(stdout, "%s%s\n", outputPrefix, strings.Replace(issue.String(), "\n", "\n"+outputPrefix, -1))
-			result.producedOutput = true
-		}
-		if err := scanner.Err(); err != nil {
-			_, _ = fmt.Fprintf(stdout, "%s%s\n", outputPrefix, "scanner error encountered while reading output")
-			result.producedOutput = true
+			issues.write(issue)
+			producedOutput = true
+		}); err != nil {
+			issues.writeError("error encountered while reading output")
+			producedOutput = true
 		}
 		done <- true
 	}()
 
 	// run check
-	checkerParam.Checker.Check(filteredPkgPaths, projectDir, pipeW)
+	okgo.RunCheck(ctx, checkerParam.Checker, pkgPaths, projectDir, pipeW)
 
 	if err := pipeW.Close(); err != nil {
 		<-done
-		_, _ = fmt.Fprintf(stdout, "%s%s\n", outputPrefix, "failed to close pipe writer")
-		result.producedOutput = true
-		return result
+		issues.writeError("failed to close pipe writer")
+		return true
 	}
 
 	// wait until all output has been read
 	<-done
 
-	_, _ = fmt.Fprintf(stdout, "%sFinished %s\n", outputPrefix, checkerType)
-
-	return result
+	return producedOutput
 }
 
 func getFilteredPkgPaths(checkerParam okgo.CheckerParam, pkgPaths []string) []string {
 	var filteredPkgPaths []string
 	for _, pkgPath := range pkgPaths {
+		if checkerParam.Include != nil && !includesPkg(checkerParam.Include, pkgPath) {
+			// skip packages that are not included
+			continue
+		}
 		if checkerParam.Exclude != nil && checkerParam.Exclude.Match(pkgPath) {
 			// skip excludes
 			continue
@@ -281,7 +726,33 @@ func getFilteredPkgPaths(checkerParam okgo.CheckerParam, pkgPaths []string) []st
 	return filteredPkgPaths
 }
 
+// includesPkg returns true if the provided include matcher matches the package path or any of the Go files in the
+// package directory.
+func includesPkg(include matcher.Matcher, pkgPath string) bool {
+	pkgPath = path.Clean(pkgPath)
+	if include.Match(pkgPath) {
+		return true
+	}
+	entries, err := os.ReadDir(pkgPath)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		if include.Match(path.Join(pkgPath, entry.Name())) {
+			return true
+		}
+	}
+	return false
+}
+
 func shouldSkipIssue(issue okgo.Issue, checkerParam okgo.CheckerParam) bool {
+	if issue.Path != "" && checkerParam.Include != nil && !checkerParam.Include.Match(issue.Path) {
+		// if path is not included, skip
+		return true
+	}
 	if issue.Path != "" && checkerParam.Exclude != nil && checkerParam.Exclude.Match(issue.Path) {
 		// if path matches exclude, skip
 		return true
@@ -16,16 +16,24 @@ package check
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/palantir/okgo/okgo"
+	"github.com/palantir/okgo/okgo/trace"
 	"github.com/palantir/pkg/matcher"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type inMemoryChecker struct {
@@ -241,6 +249,715 @@ func TestRun_NoErrorsWithWaitsAndSplit(t *testing.T) {
 	assert.Less(t, time.Now().Sub(start), timeToWait*4)
 }
 
+func TestRun_NoErrorsWithWaitsAndResources(t *testing.T) {
+	timeToWait := time.Millisecond * 50
+	newChecker := func(checkerType okgo.CheckerType, resources okgo.CheckerResources) okgo.Checker {
+		return &resourceChecker{
+			inMemoryChecker: inMemoryChecker{
+				checkerType: checkerType,
+				timeToWait:  toDuration(timeToWait),
+			},
+			resources: resources,
+		}
+	}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"test1": {Checker: newChecker("test1", okgo.CheckerResources{CPU: 2})},
+			"test2": {Checker: newChecker("test2", okgo.CheckerResources{})},
+			"test3": {Checker: newChecker("test3", okgo.CheckerResources{CPU: 2})},
+			"test4": {Checker: newChecker("test4", okgo.CheckerResources{})},
+		},
+	}
+	checkersToRun := []okgo.CheckerType{
+		"test1",
+		"test2",
+		"test3",
+		"test4",
+	}
+	// test1 and test3 use all 4 CPUs, then test2 and test4 run together
+	start := time.Now()
+	err := Run(projectParam, checkersToRun, nil, "dir", nil, 4, os.Stdout)
+	assert.NoError(t, err)
+	assert.Greater(t, time.Now().Sub(start), timeToWait*2)
+	assert.Less(t, time.Now().Sub(start), timeToWait*3)
+
+	// memory capacity only allows 2 checks to run at the same time
+	projectParam.Checks["test2"] = okgo.CheckerParam{Checker: newChecker("test2", okgo.CheckerResources{MemoryMB: 512})}
+	projectParam.Checks["test4"] = okgo.CheckerParam{Checker: newChecker("test4", okgo.CheckerResources{MemoryMB: 512})}
+	start = time.Now()
+	err = Run(projectParam, checkersToRun[1:], nil, "dir", nil, 4, os.Stdout, RunParamMemoryCapacityMB(768))
+	assert.NoError(t, err)
+	assert.Greater(t, time.Now().Sub(start), timeToWait*2)
+	assert.Less(t, time.Now().Sub(start), timeToWait*3)
+}
+
+type resourceChecker struct {
+	inMemoryChecker
+	resources okgo.CheckerResources
+}
+
+func (r *resourceChecker) Resources() (okgo.CheckerResources, error) {
+	return r.resources, nil
+}
+
+type recordingChecker struct {
+	inMemoryChecker
+	mutex    sync.Mutex
+	pkgPaths [][]string
+}
+
+func (r *recordingChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	r.mutex.Lock()
+	r.pkgPaths = append(r.pkgPaths, pkgPaths)
+	r.mutex.Unlock()
+	r.inMemoryChecker.Check(pkgPaths, projectDir, stdout)
+}
+
+func TestRun_DirectoryParams(t *testing.T) {
+	rootChecker := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "test1"}}
+	fooChecker := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "test1"}}
+	fooBarChecker := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "test1"}}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"test1": {
+				Checker: rootChecker,
+			},
+		},
+		Directories: []okgo.DirectoryParam{
+			{
+				Dir: "foo",
+				Checks: map[okgo.CheckerType]okgo.CheckerParam{
+					"test1": {
+						Checker: fooChecker,
+					},
+				},
+			},
+			{
+				Dir: "foo/bar",
+				Checks: map[okgo.CheckerType]okgo.CheckerParam{
+					"test1": {
+						Checker: fooBarChecker,
+					},
+				},
+			},
+			{
+				Dir: "skipped",
+				Checks: map[okgo.CheckerType]okgo.CheckerParam{
+					"test1": {
+						Skip:    true,
+						Checker: fooChecker,
+					},
+				},
+			},
+		},
+	}
+	pkgPaths := []string{".", "./foo", "./foo/bar/baz", "./foobar", "./skipped/pkg"}
+	err := Run(projectParam, []okgo.CheckerType{"test1"}, pkgPaths, "dir", nil, 1, io.Discard)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{".", "./foobar"}}, rootChecker.pkgPaths)
+	assert.Equal(t, [][]string{{"./foo"}}, fooChecker.pkgPaths)
+	assert.Equal(t, [][]string{{"./foo/bar/baz"}}, fooBarChecker.pkgPaths)
+}
+
+func TestRun_DirectoryParamsWithSameParams(t *testing.T) {
+	rootChecker := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "test1"}}
+	dirChecker := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "test1"}}
+	rootParam := okgo.CheckerParam{
+		Checker: rootChecker,
+	}
+	dirParam := okgo.CheckerParam{
+		Checker: dirChecker,
+	}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"test1": rootParam,
+		},
+		Directories: []okgo.DirectoryParam{
+			{Dir: "bar", Checks: map[okgo.CheckerType]okgo.CheckerParam{"test1": dirParam}},
+			{Dir: "baz", Checks: map[okgo.CheckerType]okgo.CheckerParam{"test1": rootParam}},
+			{Dir: "foo", Checks: map[okgo.CheckerType]okgo.CheckerParam{"test1": dirParam}},
+		},
+	}
+	pkgPaths := []string{".", "./bar", "./baz", "./foo"}
+	err := Run(projectParam, []okgo.CheckerType{"test1"}, pkgPaths, "dir", nil, 1, io.Discard)
+	require.NoError(t, err)
+
+	// directories with the same parameters are checked by a single invocation
+	assert.Equal(t, [][]string{{".", "./baz"}}, rootChecker.pkgPaths)
+	assert.Equal(t, [][]string{{"./bar", "./foo"}}, dirChecker.pkgPaths)
+}
+
+func TestRun_Include(t *testing.T) {
+	checker := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "test1", issue: &okgo.Issue{
+		Path:    "bar/bar.go",
+		Content: "output",
+	}}}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"test1": {
+				Include: matcher.Path("foo"),
+				Checker: checker,
+			},
+		},
+	}
+	err := Run(projectParam, []okgo.CheckerType{"test1"}, []string{"./foo", "./bar"}, "dir", nil, 1, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"./foo"}}, checker.pkgPaths)
+}
+
+func TestRun_DependsOn(t *testing.T) {
+	compiles := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "compiles", issue: &okgo.Issue{
+		Content: "compile error",
+	}}}
+	vet := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "vet"}}
+	vetStrict := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "vet-strict"}}
+	license := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "license"}}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"compiles": {
+				Checker: compiles,
+			},
+			"vet": {
+				Checker:   vet,
+				DependsOn: []okgo.CheckerType{"compiles"},
+			},
+			"vet-strict": {
+				Checker:   vetStrict,
+				DependsOn: []okgo.CheckerType{"vet"},
+			},
+			"license": {
+				Checker:   license,
+				DependsOn: []okgo.CheckerType{"not-run"},
+			},
+		},
+	}
+	buf := &syncBuffer{}
+	err := Run(projectParam, []okgo.CheckerType{"compiles", "license", "vet", "vet-strict"}, []string{"./foo"}, "dir", nil, 2, buf)
+	require.Error(t, err)
+	assert.Equal(t, [][]string{{"./foo"}}, compiles.pkgPaths)
+	assert.Equal(t, [][]string{{"./foo"}}, license.pkgPaths)
+	assert.Empty(t, vet.pkgPaths)
+	assert.Empty(t, vetStrict.pkgPaths)
+	assert.Contains(t, buf.String(), "[vet]        Skipping vet because check(s) it depends on did not pass: [compiles]\n")
+	assert.Contains(t, buf.String(), "[vet-strict] Skipping vet-strict because check(s) it depends on did not pass: [vet]\n")
+	assert.Contains(t, buf.String(), "Check(s) produced output: [compiles]\n")
+}
+
+func TestRun_DependsOnRunsDependenciesFirst(t *testing.T) {
+	var (
+		lock  sync.Mutex
+		order []string
+	)
+	newChecker := func(checkerType okgo.CheckerType) okgo.Checker {
+		return &orderRecordingChecker{
+			inMemoryChecker: inMemoryChecker{checkerType: checkerType, timeToWait: toDuration(10 * time.Millisecond)},
+			lock:            &lock,
+			order:           &order,
+		}
+	}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"a": {
+				Checker:   newChecker("a"),
+				DependsOn: []okgo.CheckerType{"c"},
+			},
+			"b": {
+				Checker:   newChecker("b"),
+				DependsOn: []okgo.CheckerType{"a"},
+			},
+			"c": {
+				Checker: newChecker("c"),
+			},
+		},
+	}
+	err := Run(projectParam, []okgo.CheckerType{"a", "b", "c"}, nil, "dir", nil, 3, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "b"}, order)
+}
+
+func TestRun_DependencyCycle(t *testing.T) {
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"a": {
+				Checker:   &inMemoryChecker{checkerType: "a"},
+				DependsOn: []okgo.CheckerType{"b"},
+			},
+			"b": {
+				Checker:   &inMemoryChecker{checkerType: "b"},
+				DependsOn: []okgo.CheckerType{"a"},
+			},
+			"c": {
+				Checker: &inMemoryChecker{checkerType: "c"},
+			},
+		},
+	}
+	err := Run(projectParam, []okgo.CheckerType{"a", "b", "c"}, nil, "dir", nil, 2, io.Discard)
+	assert.EqualError(t, err, "dependencies between checks contain a cycle: [a b]")
+}
+
+// syncBuffer is a bytes.Buffer that can be written to concurrently.
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+func TestRun_FailFast(t *testing.T) {
+	blocking := &blockingChecker{inMemoryChecker: inMemoryChecker{checkerType: "b"}}
+	notRun := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "c"}}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"a": {
+				Checker: &inMemoryChecker{checkerType: "a", issue: &okgo.Issue{
+					Content: "output",
+				}},
+			},
+			"b": {
+				Checker: blocking,
+			},
+			"c": {
+				Checker: notRun,
+			},
+		},
+	}
+	buf := &syncBuffer{}
+	err := Run(projectParam, []okgo.CheckerType{"a", "b", "c"}, []string{"./foo"}, "dir", nil, 2, buf, RunParamFailFast(true))
+	require.Error(t, err)
+	assert.True(t, blocking.cancelled)
+	assert.Empty(t, notRun.pkgPaths)
+	assert.Contains(t, buf.String(), "[b] Cancelled b\n")
+	assert.Contains(t, buf.String(), "Check(s) not run because a check produced output: [c]\n")
+	assert.Contains(t, buf.String(), "Check(s) produced output: [a]\n")
+}
+
+func TestRun_Context(t *testing.T) {
+	blocking := &blockingChecker{inMemoryChecker: inMemoryChecker{checkerType: "a"}}
+	notRun := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "b"}}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"a": {
+				Checker: blocking,
+			},
+			"b": {
+				Checker: notRun,
+			},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	buf := &syncBuffer{}
+	var report Report
+	err := Run(projectParam, []okgo.CheckerType{"a", "b"}, []string{"./foo"}, "dir", nil, 1, buf, RunParamContext(ctx), RunParamReport(&report))
+	require.EqualError(t, err, "run was cancelled: context canceled")
+	assert.True(t, blocking.cancelled)
+	assert.Empty(t, notRun.pkgPaths)
+	assert.Equal(t, "Running a...\nCancelled a\n", buf.String())
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, CheckStatusCancelled, report.Checks[0].Status)
+	assert.Equal(t, CheckStatusCancelled, report.Checks[1].Status)
+}
+
+func TestRun_Report(t *testing.T) {
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"a": {
+				Checker: &inMemoryChecker{checkerType: "a", issue: &okgo.Issue{
+					Path:    "p1",
+					Content: "output",
+				}},
+			},
+			"b": {
+				Checker:   &inMemoryChecker{checkerType: "b"},
+				DependsOn: []okgo.CheckerType{"a"},
+			},
+			"c": {
+				Checker: &inMemoryChecker{checkerType: "c"},
+			},
+			"d": {
+				Checker: &inMemoryChecker{checkerType: "d"},
+				Skip:    true,
+			},
+			"e": {
+				Checker: &errorChecker{inMemoryChecker: inMemoryChecker{checkerType: "e"}},
+			},
+		},
+	}
+	buf := &syncBuffer{}
+	var report Report
+	err := Run(projectParam, []okgo.CheckerType{"a", "b", "c", "d", "e"}, []string{"./foo"}, "dir", nil, 2, buf, RunParamPrintSummary(true), RunParamReport(&report))
+	require.Error(t, err)
+
+	require.Len(t, report.Checks, 5)
+	for i, want := range []CheckSummary{
+		{Check: "a", Status: CheckStatusFail, IssueCount: 1, Issues: []okgo.Issue{{Path: "p1", Content: "output"}}},
+		{Check: "b", Status: CheckStatusSkipped},
+		{Check: "c", Status: CheckStatusPass},
+		{Check: "d", Status: CheckStatusSkipped},
+		// errors reported using okgo.WriteErrorAsIssue are reported as errors rather than as failures
+		{Check: "e", Status: CheckStatusError, IssueCount: 1, Issues: []okgo.Issue{{Content: "failed to run", Error: true}}},
+	} {
+		got := report.Checks[i]
+		got.Duration = 0
+		assert.Equal(t, want, got)
+	}
+	assert.Regexp(t, `CHECK +STATUS +ISSUES +DURATION\na +fail +1 +\S+\nb +skipped +0 +0s\nc +pass +0 +\S+\nd +skipped +0 +0s\ne +error +1 +\S+\nCheck\(s\) produced output: \[a e\]\n$`, buf.String())
+}
+
+// errorChecker is a checker that reports an error encountered while running its check.
+type errorChecker struct {
+	inMemoryChecker
+}
+
+func (e *errorChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	okgo.WriteErrorAsIssue(errors.New("failed to run"), stdout)
+}
+
+func TestRun_Trace(t *testing.T) {
+	trace.Enable()
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"trace-a": {
+				Checker: &inMemoryChecker{checkerType: "trace-a", timeToWait: toDuration(50 * time.Millisecond)},
+			},
+			"trace-b": {
+				Checker: &inMemoryChecker{checkerType: "trace-b", issue: &okgo.Issue{
+					Content: "output",
+				}},
+			},
+		},
+	}
+	err := Run(projectParam, []okgo.CheckerType{"trace-a", "trace-b"}, []string{"./foo"}, "dir", nil, 2, &syncBuffer{})
+	require.Error(t, err)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, trace.Write(buf))
+	var traceFile struct {
+		TraceEvents []struct {
+			Name string                 `json:"name"`
+			Cat  string                 `json:"cat"`
+			Ph   string                 `json:"ph"`
+			Dur  int64                  `json:"dur"`
+			TID  int                    `json:"tid"`
+			Args map[string]interface{} `json:"args"`
+		} `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &traceFile))
+
+	laneNames := make(map[int]string)
+	checkLanes := make(map[string]int)
+	for _, event := range traceFile.TraceEvents {
+		switch {
+		case event.Ph == "M" && event.Name == "thread_name":
+			laneNames[event.TID] = event.Args["name"].(string)
+		case event.Cat == "check" && strings.HasPrefix(event.Name, "trace-"):
+			checkLanes[event.Name] = event.TID
+			assert.Equal(t, "X", event.Ph)
+			if event.Name == "trace-a" {
+				assert.True(t, event.Dur >= (50*time.Millisecond).Microseconds())
+				assert.Equal(t, "pass", event.Args["status"])
+			} else {
+				assert.Equal(t, "fail", event.Args["status"])
+				assert.Equal(t, float64(1), event.Args["issues"])
+			}
+		}
+	}
+	require.Len(t, checkLanes, 2)
+	// the checks run at the same time, so they are run on different worker lanes
+	assert.NotEqual(t, checkLanes["trace-a"], checkLanes["trace-b"])
+	assert.True(t, strings.HasPrefix(laneNames[checkLanes["trace-a"]], "check worker "))
+	assert.True(t, strings.HasPrefix(laneNames[checkLanes["trace-b"]], "check worker "))
+}
+
+func TestRun_Durations(t *testing.T) {
+	var (
+		lock  sync.Mutex
+		order []string
+	)
+	newChecker := func(checkerType okgo.CheckerType) okgo.Checker {
+		return &orderRecordingChecker{
+			inMemoryChecker: inMemoryChecker{checkerType: checkerType},
+			lock:            &lock,
+			order:           &order,
+		}
+	}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"a": {Checker: newChecker("a")},
+			"b": {Checker: newChecker("b")},
+			"c": {Checker: newChecker("c")},
+			"d": {Checker: newChecker("d")},
+		},
+	}
+	durations := Durations{
+		"a": time.Second,
+		"c": 5 * time.Second,
+		"d": 2 * time.Second,
+	}
+	err := Run(projectParam, []okgo.CheckerType{"a", "b", "c", "d"}, nil, "dir", nil, 1, io.Discard, RunParamDurations(durations))
+	require.NoError(t, err)
+	// checks without a recorded duration are run first, followed by the checks that took the longest
+	assert.Equal(t, []string{"b", "c", "d", "a"}, order)
+	assert.Len(t, durations, 4)
+	assert.True(t, durations["c"] < 5*time.Second)
+}
+
+func TestDurations_SaveAndLoad(t *testing.T) {
+	durationsFile := filepath.Join(t.TempDir(), "durations", "durations.json")
+	durations, err := LoadDurations(durationsFile)
+	require.NoError(t, err)
+	assert.Empty(t, durations)
+
+	durations["errcheck"] = 3 * time.Second
+	require.NoError(t, durations.Save(durationsFile))
+	loaded, err := LoadDurations(durationsFile)
+	require.NoError(t, err)
+	assert.Equal(t, Durations{"errcheck": 3 * time.Second}, loaded)
+
+	// saving replaces the existing file without leaving temporary files behind
+	require.NoError(t, Durations{"golint": time.Second}.Save(durationsFile))
+	loaded, err = LoadDurations(durationsFile)
+	require.NoError(t, err)
+	assert.Equal(t, Durations{"golint": time.Second}, loaded)
+	entries, err := os.ReadDir(filepath.Dir(durationsFile))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "durations.json", entries[0].Name())
+}
+
+func TestRun_GroupOutput(t *testing.T) {
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"aa": {
+				Checker: &multiIssueChecker{
+					inMemoryChecker: inMemoryChecker{checkerType: "aa", timeToWait: toDuration(50 * time.Millisecond)},
+					issues: []okgo.Issue{
+						{Path: "foo.go", Line: 3, Col: 1, Content: "second"},
+						{Path: "foo.go", Line: 1, Col: 1, Content: "first"},
+					},
+				},
+			},
+			"b": {
+				Checker: &multiIssueChecker{
+					inMemoryChecker: inMemoryChecker{checkerType: "b"},
+					issues: []okgo.Issue{
+						{Path: "bar.go", Line: 2, Col: 1, Content: "issue"},
+					},
+				},
+			},
+		},
+	}
+	buf := &syncBuffer{}
+	err := Run(projectParam, []okgo.CheckerType{"aa", "b"}, nil, "dir", nil, 2, buf, RunParamGroupOutput(true))
+	require.Error(t, err)
+	assert.Equal(t, `[aa] Running aa...
+[aa] foo.go:1:1: first
+[aa] foo.go:3:1: second
+[aa] Finished aa
+[b]  Running b...
+[b]  bar.go:2:1: issue
+[b]  Finished b
+Check(s) produced output: [aa b]
+`, buf.String())
+}
+
+type multiIssueChecker struct {
+	inMemoryChecker
+	issues []okgo.Issue
+}
+
+func (m *multiIssueChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	m.inMemoryChecker.Check(pkgPaths, projectDir, stdout)
+	for _, issue := range m.issues {
+		bytes, _ := json.Marshal(issue)
+		_, _ = fmt.Fprintln(stdout, string(bytes))
+	}
+}
+
+// blockingChecker is a checker whose check runs until it is cancelled.
+type blockingChecker struct {
+	inMemoryChecker
+	cancelled bool
+}
+
+func (b *blockingChecker) CheckWithContext(ctx context.Context, pkgPaths []string, projectDir string, stdout io.Writer) {
+	<-ctx.Done()
+	b.cancelled = true
+}
+
+type orderRecordingChecker struct {
+	inMemoryChecker
+	lock  *sync.Mutex
+	order *[]string
+}
+
+func (o *orderRecordingChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	o.inMemoryChecker.Check(pkgPaths, projectDir, stdout)
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	*o.order = append(*o.order, string(o.checkerType))
+}
+
+func TestRun_Shards(t *testing.T) {
+	checker := &recordingChecker{inMemoryChecker: inMemoryChecker{checkerType: "test1", issue: &okgo.Issue{
+		Content: "output",
+	}}}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"test1": {
+				Checker: checker,
+				Shards:  2,
+			},
+		},
+	}
+	buf := &syncBuffer{}
+	err := Run(projectParam, []okgo.CheckerType{"test1"}, []string{"./a", "./b", "./c", "./d", "./e"}, "dir", nil, 1, buf)
+	require.Error(t, err)
+	sort.Slice(checker.pkgPaths, func(i, j int) bool {
+		return checker.pkgPaths[i][0] < checker.pkgPaths[j][0]
+	})
+	assert.Equal(t, [][]string{{"./a", "./b", "./c"}, {"./d", "./e"}}, checker.pkgPaths)
+	// issues from all of the shards are reported
+	assert.Equal(t, 2, strings.Count(buf.String(), "output\n"))
+}
+
+func TestRun_ShardsLimitedToReservedCPUs(t *testing.T) {
+	for i, tc := range []struct {
+		parallelism int
+		resources   okgo.CheckerResources
+		want        int
+	}{
+		{1, okgo.CheckerResources{}, 1},
+		{2, okgo.CheckerResources{}, 2},
+		{4, okgo.CheckerResources{CPU: 2}, 2},
+		{4, okgo.CheckerResources{CPU: 4}, 1},
+	} {
+		timeToWait := 50 * time.Millisecond
+		checker := &concurrencyRecordingChecker{resourceChecker: resourceChecker{
+			inMemoryChecker: inMemoryChecker{checkerType: "test1", timeToWait: &timeToWait},
+			resources:       tc.resources,
+		}}
+		projectParam := okgo.ProjectParam{
+			Checks: map[okgo.CheckerType]okgo.CheckerParam{
+				"test1": {
+					Checker: checker,
+					Shards:  5,
+				},
+			},
+		}
+		err := Run(projectParam, []okgo.CheckerType{"test1"}, []string{"./a", "./b", "./c", "./d", "./e"}, "dir", nil, tc.parallelism, io.Discard)
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, 5, checker.calls, "Case %d", i)
+		assert.Equal(t, tc.want, checker.maxRunning, "Case %d", i)
+	}
+}
+
+// concurrencyRecordingChecker records the maximum number of concurrent invocations of its check.
+type concurrencyRecordingChecker struct {
+	resourceChecker
+	mutex      sync.Mutex
+	running    int
+	maxRunning int
+	calls      int
+}
+
+func (c *concurrencyRecordingChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {
+	c.mutex.Lock()
+	c.running++
+	c.calls++
+	c.maxRunning = max(c.maxRunning, c.running)
+	c.mutex.Unlock()
+	c.resourceChecker.Check(pkgPaths, projectDir, stdout)
+	c.mutex.Lock()
+	c.running--
+	c.mutex.Unlock()
+}
+
+func TestShardPkgPaths(t *testing.T) {
+	pkgPaths := []string{"a", "b", "c", "d", "e"}
+	for i, tc := range []struct {
+		shards    int
+		shardSize int
+		want      [][]string
+	}{
+		{0, 0, [][]string{{"a", "b", "c", "d", "e"}}},
+		{1, 0, [][]string{{"a", "b", "c", "d", "e"}}},
+		{2, 0, [][]string{{"a", "b", "c"}, {"d", "e"}}},
+		{3, 0, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+		{10, 0, [][]string{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}}},
+		{0, 2, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+		{0, 5, [][]string{{"a", "b", "c", "d", "e"}}},
+	} {
+		assert.Equal(t, tc.want, shardPkgPaths(pkgPaths, tc.shards, tc.shardSize), "Case %d", i)
+	}
+	assert.Equal(t, [][]string{nil}, shardPkgPaths(nil, 4, 0))
+}
+
+func TestRun_LongIssue(t *testing.T) {
+	// issue content is much larger than the default maximum token size of bufio.Scanner
+	content := strings.Repeat("x", 5*1024*1024)
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"long": {
+				Checker: &inMemoryChecker{checkerType: "long", issue: &okgo.Issue{
+					Path:    "p1",
+					Content: content,
+				}},
+			},
+		},
+	}
+	var report Report
+	err := Run(projectParam, []okgo.CheckerType{"long"}, []string{"./foo"}, "dir", nil, 1, io.Discard, RunParamReport(&report))
+	require.Error(t, err)
+
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, CheckStatusFail, report.Checks[0].Status)
+	assert.Equal(t, []okgo.Issue{{Path: "p1", Content: content}}, report.Checks[0].Issues)
+}
+
+func TestRun_RelatedLocations(t *testing.T) {
+	issue := okgo.Issue{
+		Path:    "foo.go",
+		Line:    8,
+		Col:     7,
+		EndLine: 8,
+		EndCol:  8,
+		Content: "assignment copies lock value",
+		Related: []okgo.RelatedLocation{
+			{Path: "foo.go", Line: 5, Col: 2, EndLine: 5, EndCol: 12, Message: "lock declared here"},
+		},
+	}
+	projectParam := okgo.ProjectParam{
+		Checks: map[okgo.CheckerType]okgo.CheckerParam{
+			"copylocks": {
+				Checker: &inMemoryChecker{checkerType: "copylocks", issue: &issue},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+	var report Report
+	err := Run(projectParam, []okgo.CheckerType{"copylocks"}, []string{"./foo"}, "dir", nil, 1, buf, RunParamReport(&report))
+	require.Error(t, err)
+
+	// related locations are printed on the lines that follow the issue
+	assert.Contains(t, buf.String(), "foo.go:8:7: assignment copies lock value\n\tfoo.go:5:2: lock declared here\n")
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, []okgo.Issue{issue}, report.Checks[0].Issues)
+}
+
 func toDuration(timeToWait time.Duration) *time.Duration {
 	return &timeToWait
 }
@@ -0,0 +1,82 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/pkg/errors"
+)
+
+// Durations records the wall-clock duration of the most recent run of each check. It is used to start the checks that
+// take the longest first.
+type Durations map[okgo.CheckerType]time.Duration
+
+// LoadDurations loads the durations stored in the specified file. Returns empty durations if the file does not exist.
+func LoadDurations(durationsFile string) (Durations, error) {
+	bytes, err := os.ReadFile(durationsFile)
+	if os.IsNotExist(err) {
+		return Durations{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read durations file")
+	}
+	durations := Durations{}
+	if err := json.Unmarshal(bytes, &durations); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal durations file %s", durationsFile)
+	}
+	return durations, nil
+}
+
+// Save writes the durations to the specified file, creating its parent directories if necessary. The durations are
+// written to a temporary file that is then renamed to the specified file, so concurrent runs never observe (or leave
+// behind) a partially written file.
+func (d Durations) Save(durationsFile string) (rErr error) {
+	bytes, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal durations")
+	}
+	durationsDir := filepath.Dir(durationsFile)
+	if err := os.MkdirAll(durationsDir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create directory for durations file")
+	}
+	tmpFile, err := os.CreateTemp(durationsDir, filepath.Base(durationsFile)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary durations file")
+	}
+	defer func() {
+		if rErr != nil {
+			_ = os.Remove(tmpFile.Name())
+		}
+	}()
+	if _, err := tmpFile.Write(bytes); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "failed to write durations file")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write durations file")
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return errors.Wrapf(err, "failed to set permissions of durations file")
+	}
+	if err := os.Rename(tmpFile.Name(), durationsFile); err != nil {
+		return errors.Wrapf(err, "failed to write durations file")
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/palantir/okgo/okgo"
+)
+
+// runOutput manages the output of the jobs of a run. If grouped is false, the output of every job is written to stdout
+// directly. Otherwise, the output of each job is buffered and is written to stdout once the job and all of the jobs
+// that precede it are done, so that the output of each job is contiguous and the jobs are written in order.
+type runOutput struct {
+	stdout  io.Writer
+	grouped bool
+	buffers []bytes.Buffer
+	done    []bool
+	next    int
+}
+
+func newRunOutput(stdout io.Writer, numJobs int, grouped bool) *runOutput {
+	out := &runOutput{
+		stdout:  stdout,
+		grouped: grouped,
+	}
+	if grouped {
+		out.buffers = make([]bytes.Buffer, numJobs)
+		out.done = make([]bool, numJobs)
+	}
+	return out
+}
+
+// jobWriter returns the writer for the output of the job with the provided index. If output is grouped, the returned
+// writer must only be written to by a single goroutine at a time.
+func (o *runOutput) jobWriter(idx int) io.Writer {
+	if !o.grouped {
+		return o.stdout
+	}
+	return &o.buffers[idx]
+}
+
+// jobDone records that the job with the provided index will not produce any more output and writes the output of all of
+// the jobs that can be written.
+func (o *runOutput) jobDone(idx int) {
+	if !o.grouped {
+		return
+	}
+	o.done[idx] = true
+	for o.next < len(o.done) && o.done[o.next] {
+		_, _ = o.stdout.Write(o.buffers[o.next].Bytes())
+		o.buffers[o.next] = bytes.Buffer{}
+		o.next++
+	}
+}
+
+// issueWriter writes the issues reported by a check to stdout with a prefix. If buffered is true, issues are not
+// written until flush is called, at which point they are written in order of path and position. If record is true,
+// all of the issues that are written are recorded. It is safe for concurrent use.
+type issueWriter struct {
+	stdout   io.Writer
+	prefix   string
+	buffered bool
+	record   bool
+
+	lock   sync.Mutex
+	issues []okgo.Issue
+
+	// count is the number of issues that were written.
+	count int
+	// errored is true if an error that prevented the check from running properly was written.
+	errored  bool
+	recorded []okgo.Issue
+}
+
+// writeError writes an issue that describes an error encountered while running the check.
+func (w *issueWriter) writeError(msg string) {
+	w.write(okgo.Issue{Content: msg, Error: true})
+}
+
+// write writes the provided issue. If the issue is marked as an error, the check is recorded as having errored.
+func (w *issueWriter) write(issue okgo.Issue) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.count++
+	if issue.Error {
+		w.errored = true
+	}
+	if w.record {
+		w.recorded = append(w.recorded, issue)
+	}
+	if w.buffered {
+		w.issues = append(w.issues, issue)
+		return
+	}
+	w.writeIssue(issue)
+}
+
+// flush writes all of the buffered issues.
+func (w *issueWriter) flush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	sort.SliceStable(w.issues, func(i, j int) bool {
+		return issueLess(w.issues[i], w.issues[j])
+	})
+	for _, issue := range w.issues {
+		w.writeIssue(issue)
+	}
+	w.issues = nil
+}
+
+func (w *issueWriter) writeIssue(issue okgo.Issue) {
+	_, _ = fmt.Fprintf(w.stdout, "%s%s\n", w.prefix, strings.Replace(issue.String(), "\n", "\n"+w.prefix, -1))
+}
+
+// issueLess returns true if issue i should be ordered before issue j. Issues are ordered by path, line, column and
+// content.
+func issueLess(i, j okgo.Issue) bool {
+	if i.Path != j.Path {
+		return i.Path < j.Path
+	}
+	if i.Line != j.Line {
+		return i.Line < j.Line
+	}
+	if i.Col != j.Col {
+		return i.Col < j.Col
+	}
+	return i.Content < j.Content
+}
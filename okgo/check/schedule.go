@@ -0,0 +1,288 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"sort"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/pkg/errors"
+)
+
+type jobState int
+
+const (
+	jobPending jobState = iota
+	jobRunning
+	jobPassed
+	jobFailed
+	// jobSkipped indicates that the job was not run because one of its dependencies did not pass or because the run
+	// was cancelled.
+	jobSkipped
+)
+
+// scheduler determines the order in which check jobs are run. A job is started once all of its dependencies have
+// passed, and is skipped if any of its dependencies fail or are skipped. At most parallelism jobs are run at the same
+// time, and jobs are only started if the total resources used by the running jobs do not exceed the capacity (a job is
+// always started if no other job is running). Among the jobs that are ready to run, the jobs that use the most CPUs
+// are started first, followed by the other jobs in the order in which they were provided (which is the priority
+// order). Jobs are started in this order: a job that does not fit within the remaining capacity is not passed by the
+// jobs that follow it.
+type scheduler struct {
+	jobs        []checkJob
+	resources   []okgo.CheckerResources
+	parallelism int
+	capacity    okgo.CheckerResources
+
+	// concurrentShards is the maximum number of shards of each job that run at the same time. It is the number of
+	// shards whose CPUs fit within the CPUs reserved for the job.
+	concurrentShards []int
+
+	// order is the order in which ready jobs are considered for starting.
+	order         []int
+	dependencies  [][]int
+	dependents    [][]int
+	remainingDeps []int
+	states        []jobState
+
+	running  int
+	used     okgo.CheckerResources
+	finished int
+}
+
+// newScheduler returns a scheduler for the provided jobs. If the memory of the provided capacity is 0, memory usage is
+// not considered when starting jobs.
+func newScheduler(jobs []checkJob, parallelism int, capacity okgo.CheckerResources) (*scheduler, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if capacity.CPU < 1 {
+		capacity.CPU = 1
+	}
+	s := &scheduler{
+		jobs:             jobs,
+		resources:        make([]okgo.CheckerResources, len(jobs)),
+		concurrentShards: make([]int, len(jobs)),
+		parallelism:      parallelism,
+		capacity:         capacity,
+		dependencies:     make([][]int, len(jobs)),
+		dependents:       make([][]int, len(jobs)),
+		remainingDeps:    make([]int, len(jobs)),
+		states:           make([]jobState, len(jobs)),
+	}
+
+	jobIdx := make(map[okgo.CheckerType]int)
+	for i, job := range jobs {
+		jobIdx[job.checkerType] = i
+	}
+	for i, job := range jobs {
+		resources, err := jobResources(job.Checker, capacity.CPU)
+		if err != nil {
+			return nil, err
+		}
+		s.concurrentShards[i] = 1
+		if job.shards > 1 {
+			// each shard is a separate invocation of the check
+			shardCPU := resources.CPU
+			resources.CPU *= job.shards
+			resources.MemoryMB *= job.shards
+			if resources.CPU > capacity.CPU {
+				resources.CPU = capacity.CPU
+			}
+			s.concurrentShards[i] = max(resources.CPU/shardCPU, 1)
+		}
+		s.resources[i] = resources
+
+		for _, dependency := range job.dependsOn() {
+			depIdx, ok := jobIdx[dependency]
+			if !ok || depIdx == i {
+				// dependencies on checks that are not being run are ignored
+				continue
+			}
+			s.dependencies[i] = append(s.dependencies[i], depIdx)
+			s.dependents[depIdx] = append(s.dependents[depIdx], i)
+			s.remainingDeps[i]++
+		}
+	}
+	if err := s.verifyAcyclic(); err != nil {
+		return nil, err
+	}
+
+	for i := range jobs {
+		s.order = append(s.order, i)
+	}
+	sort.SliceStable(s.order, func(i, j int) bool {
+		return s.resources[s.order[i]].CPU > s.resources[s.order[j]].CPU
+	})
+	return s, nil
+}
+
+// jobResources returns the resources used by the provided checker. If the checker does not declare the number of CPUs
+// that it uses, it is considered to use cpuCapacity CPUs if it uses multiple CPUs and 1 CPU otherwise. The returned
+// number of CPUs is at most cpuCapacity.
+func jobResources(checker okgo.Checker, cpuCapacity int) (okgo.CheckerResources, error) {
+	var resources okgo.CheckerResources
+	if resourceChecker, ok := checker.(okgo.ResourceChecker); ok {
+		declared, err := resourceChecker.Resources()
+		if err != nil {
+			return okgo.CheckerResources{}, err
+		}
+		resources = declared
+	}
+	if resources.CPU <= 0 {
+		multiCPU, err := checker.MultiCPU()
+		if err != nil {
+			return okgo.CheckerResources{}, err
+		}
+		resources.CPU = 1
+		if multiCPU {
+			resources.CPU = cpuCapacity
+		}
+	}
+	if resources.CPU > cpuCapacity {
+		resources.CPU = cpuCapacity
+	}
+	return resources, nil
+}
+
+// verifyAcyclic returns an error if the dependencies between the jobs contain a cycle.
+func (s *scheduler) verifyAcyclic() error {
+	remaining := append([]int(nil), s.remainingDeps...)
+	var queue []int
+	for i := range s.jobs {
+		if remaining[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	visited := 0
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, dependent := range s.dependents[curr] {
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	if visited == len(s.jobs) {
+		return nil
+	}
+	var cyclic []string
+	for i := range s.jobs {
+		if remaining[i] > 0 {
+			cyclic = append(cyclic, string(s.jobs[i].checkerType))
+		}
+	}
+	sort.Strings(cyclic)
+	return errors.Errorf("dependencies between checks contain a cycle: %v", cyclic)
+}
+
+// next marks the jobs that can be started and returns their indices.
+func (s *scheduler) next() []int {
+	var toStart []int
+	for _, i := range s.order {
+		if s.states[i] != jobPending || s.remainingDeps[i] > 0 {
+			continue
+		}
+		if s.running >= s.parallelism || !s.fits(i) {
+			break
+		}
+		s.start(i)
+		toStart = append(toStart, i)
+	}
+	return toStart
+}
+
+// fits returns true if the job with the provided index can be started without exceeding the capacity.
+func (s *scheduler) fits(i int) bool {
+	if s.running == 0 {
+		return true
+	}
+	if s.used.CPU+s.resources[i].CPU > s.capacity.CPU {
+		return false
+	}
+	return s.capacity.MemoryMB <= 0 || s.used.MemoryMB+s.resources[i].MemoryMB <= s.capacity.MemoryMB
+}
+
+func (s *scheduler) start(i int) {
+	s.states[i] = jobRunning
+	s.running++
+	s.used.CPU += s.resources[i].CPU
+	s.used.MemoryMB += s.resources[i].MemoryMB
+}
+
+// finish records the result of the job with the provided index. Returns the indices of the jobs that will not be run
+// as a result (because the job failed), in the order in which they were provided.
+func (s *scheduler) finish(i int, passed bool) []int {
+	s.running--
+	s.used.CPU -= s.resources[i].CPU
+	s.used.MemoryMB -= s.resources[i].MemoryMB
+	s.finished++
+	if passed {
+		s.states[i] = jobPassed
+		for _, dependent := range s.dependents[i] {
+			s.remainingDeps[dependent]--
+		}
+		return nil
+	}
+	s.states[i] = jobFailed
+
+	var skipped []int
+	queue := append([]int(nil), s.dependents[i]...)
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if s.states[curr] != jobPending {
+			continue
+		}
+		s.states[curr] = jobSkipped
+		s.finished++
+		skipped = append(skipped, curr)
+		queue = append(queue, s.dependents[curr]...)
+	}
+	sort.Ints(skipped)
+	return skipped
+}
+
+// cancel marks all of the jobs that have not been started as skipped. Returns the indices of the jobs that were marked.
+func (s *scheduler) cancel() []int {
+	var cancelled []int
+	for i, state := range s.states {
+		if state != jobPending {
+			continue
+		}
+		s.states[i] = jobSkipped
+		s.finished++
+		cancelled = append(cancelled, i)
+	}
+	return cancelled
+}
+
+// failedDependencies returns the types of the dependencies of the job with the provided index that did not pass.
+func (s *scheduler) failedDependencies(i int) []okgo.CheckerType {
+	var failed []okgo.CheckerType
+	for _, dependency := range s.dependencies[i] {
+		if s.states[dependency] == jobFailed || s.states[dependency] == jobSkipped {
+			failed = append(failed, s.jobs[dependency].checkerType)
+		}
+	}
+	return failed
+}
+
+func (s *scheduler) done() bool {
+	return s.finished == len(s.jobs)
+}
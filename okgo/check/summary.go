@@ -0,0 +1,85 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/palantir/okgo/okgo"
+	"github.com/pkg/errors"
+)
+
+// CheckStatus is the status of a check in a run.
+type CheckStatus string
+
+const (
+	// CheckStatusPass indicates that the check ran and did not report any issues.
+	CheckStatusPass CheckStatus = "pass"
+	// CheckStatusFail indicates that the check ran and reported issues.
+	CheckStatusFail CheckStatus = "fail"
+	// CheckStatusError indicates that an error occurred while running the check.
+	CheckStatusError CheckStatus = "error"
+	// CheckStatusSkipped indicates that the check was not run because it is configured to be skipped or because a
+	// check that it depends on did not pass.
+	CheckStatusSkipped CheckStatus = "skipped"
+	// CheckStatusCancelled indicates that the check was cancelled or not started because another check failed and the
+	// run was stopped.
+	CheckStatusCancelled CheckStatus = "cancelled"
+)
+
+// CheckSummary is the result of a check in a run.
+type CheckSummary struct {
+	Check  okgo.CheckerType `json:"check"`
+	Status CheckStatus      `json:"status"`
+	// IssueCount is the number of issues reported by the check (after filtering).
+	IssueCount int `json:"issueCount"`
+	// Duration is the wall-clock duration of the check. It is serialized as a number of nanoseconds.
+	Duration time.Duration `json:"duration"`
+	// Issues are the issues reported by the check.
+	Issues []okgo.Issue `json:"issues,omitempty"`
+}
+
+// Report is the result of a run.
+type Report struct {
+	// Checks are the results of the checks in the order in which they were prioritized.
+	Checks []CheckSummary `json:"checks"`
+}
+
+// Save writes the JSON representation of the report to the specified file.
+func (r *Report) Save(reportFile string) error {
+	bytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal report")
+	}
+	if err := os.WriteFile(reportFile, bytes, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write report file")
+	}
+	return nil
+}
+
+// writeSummaryTable writes a table that summarizes the results of the provided checks.
+func writeSummaryTable(w io.Writer, summaries []CheckSummary) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHECK\tSTATUS\tISSUES\tDURATION")
+	for _, summary := range summaries {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", summary.Check, summary.Status, summary.IssueCount, summary.Duration.Round(time.Millisecond))
+	}
+	_ = tw.Flush()
+}
@@ -15,12 +15,15 @@
 package okgo
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -29,6 +32,16 @@ type CheckerPriority int
 
 type CheckerMultiCPU bool
 
+// CheckerResources describes the resources that a check uses while it is running.
+type CheckerResources struct {
+	// CPU is the number of CPUs that the check uses. If 0, the check is considered to use all of the available CPUs if
+	// its MultiCPU function returns true and a single CPU otherwise.
+	CPU int `json:"cpu,omitempty"`
+
+	// MemoryMB is the estimated peak memory usage of the check in megabytes. If 0, the memory usage is unknown.
+	MemoryMB int `json:"memoryMB,omitempty"`
+}
+
 type Checker interface {
 	// Type returns the type of this Checker.
 	Type() (CheckerType, error)
@@ -36,7 +49,9 @@ type Checker interface {
 	// Priority returns the priority of the check. A lower number indicates a higher priority (will be run earlier).
 	Priority() (CheckerPriority, error)
 
-	// MultiCPU returns if the check uses multiple CPUs in its check command
+	// MultiCPU returns if the check uses multiple CPUs in its check command. A check that uses multiple CPUs is
+	// considered to use all of the available CPUs: checkers that use a specific number of CPUs should implement
+	// ResourceChecker instead.
 	MultiCPU() (CheckerMultiCPU, error)
 
 	// Check runs the check on the specified packages and writes the output to the provided io.Writer. All output
@@ -51,12 +66,78 @@ type Checker interface {
 	RunCheckCmd(args []string, stdout io.Writer)
 }
 
+// ContextChecker is a Checker whose check can be cancelled. If a Checker implements this interface, CheckWithContext is
+// used to run the check rather than Check.
+type ContextChecker interface {
+	Checker
+
+	// CheckWithContext runs the check in the same manner as Check, but stops the check if the provided context is done
+	// before the check completes. If the check is stopped, it should not write any output describing the cancellation.
+	CheckWithContext(ctx context.Context, pkgPaths []string, projectDir string, stdout io.Writer)
+}
+
+// ResourceChecker is a Checker that declares the resources that its check uses. The declared resources are used to
+// determine which checks can be run at the same time.
+type ResourceChecker interface {
+	Checker
+
+	// Resources returns the resources used by the check.
+	Resources() (CheckerResources, error)
+}
+
+// RunCheck runs the check of the provided Checker. If the Checker is a ContextChecker, the check is run using the
+// provided context. Otherwise, the check is run using Check and runs to completion regardless of the context.
+func RunCheck(ctx context.Context, checker Checker, pkgPaths []string, projectDir string, stdout io.Writer) {
+	if contextChecker, ok := checker.(ContextChecker); ok {
+		contextChecker.CheckWithContext(ctx, pkgPaths, projectDir, stdout)
+		return
+	}
+	checker.Check(pkgPaths, projectDir, stdout)
+}
+
 type CheckerFactory interface {
 	Types() []CheckerType
 	NewChecker(checkerType CheckerType, cfgYMLBytes []byte) (Checker, error)
 	ConfigUpgrader(checkerType CheckerType) (ConfigUpgrader, error)
 }
 
+// TagsCheckerFactory is a CheckerFactory that provides the tags advertised by its checkers.
+type TagsCheckerFactory interface {
+	CheckerFactory
+
+	// Tags returns the tags advertised by the checker of the specified type.
+	Tags(checkerType CheckerType) ([]string, error)
+}
+
+// CheckerTags returns the tags advertised by the checker of the specified type. Returns nil if the provided factory is
+// not a TagsCheckerFactory.
+func CheckerTags(factory CheckerFactory, checkerType CheckerType) ([]string, error) {
+	tagsFactory, ok := factory.(TagsCheckerFactory)
+	if !ok {
+		return nil, nil
+	}
+	return tagsFactory.Tags(checkerType)
+}
+
+// ConfigSchemaCheckerFactory is a CheckerFactory that provides the JSON Schemas for the configuration of its checkers.
+type ConfigSchemaCheckerFactory interface {
+	CheckerFactory
+
+	// ConfigSchema returns the JSON Schema for the "config" block of the configuration for the checker of the specified
+	// type. Returns nil if the checker does not provide a schema.
+	ConfigSchema(checkerType CheckerType) (json.RawMessage, error)
+}
+
+// CheckerConfigSchema returns the JSON Schema for the "config" block of the configuration for the checker of the
+// specified type. Returns nil if the provided factory is not a ConfigSchemaCheckerFactory.
+func CheckerConfigSchema(factory CheckerFactory, checkerType CheckerType) (json.RawMessage, error) {
+	configSchemaFactory, ok := factory.(ConfigSchemaCheckerFactory)
+	if !ok {
+		return nil, nil
+	}
+	return configSchemaFactory.ConfigSchema(checkerType)
+}
+
 // NewIssueFromJSON creates an Issue from the provided input. If the provided input is the JSON representation of an
 // Issue, it is decoded and returned. Otherwise, a new Issue is created with the content set to the provided string and
 // all other fields zero'd out.
@@ -110,9 +191,34 @@ func NewIssueFromLine(in, wd string) Issue {
 	return issue
 }
 
+// ReadLines calls fn with each line read from the provided reader with its trailing "\n" or "\r\n" removed. Unlike
+// bufio.Scanner, there is no limit on the length of a line, so very large issues (such as issues that include fix data
+// or code snippets) are read in full. Returns an error if reading from the reader fails.
+func ReadLines(r io.Reader, fn func(line string)) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			line = strings.TrimSuffix(line, "\n")
+			line = strings.TrimSuffix(line, "\r")
+			fn(line)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// WriteErrorAsIssue writes the provided error to stdout as the JSON representation of an Issue whose content is the
+// error message. The issue is marked as an error so that the check is reported as having failed to run rather than as
+// having found an issue.
 func WriteErrorAsIssue(err error, stdout io.Writer) {
 	issue := Issue{
 		Content: err.Error(),
+		Error:   true,
 	}
 	bytes, err := json.Marshal(issue)
 	if err != nil {
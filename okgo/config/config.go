@@ -15,7 +15,10 @@
 package config
 
 import (
+	"context"
+	"io"
 	"regexp"
+	"slices"
 
 	"github.com/palantir/okgo/okgo"
 	v0 "github.com/palantir/okgo/okgo/config/internal/v0"
@@ -27,25 +30,51 @@ import (
 type ProjectConfig v0.ProjectConfig
 
 func (c *ProjectConfig) ToParam(factory okgo.CheckerFactory) (okgo.ProjectParam, error) {
+	if factory == nil {
+		return okgo.ProjectParam{}, errors.Errorf("factory must be provided")
+	}
+	return c.toParam(factory.Types(), factory)
+}
+
+func (c *ProjectConfig) toParam(checkerTypes []okgo.CheckerType, creator checkerCreator) (okgo.ProjectParam, error) {
+	expandedCfg, err := c.expandEnv(newEnvExpander(c.FailOnUndefinedEnv))
+	if err != nil {
+		return okgo.ProjectParam{}, err
+	}
+	c = &expandedCfg
+
 	var checks map[okgo.CheckerType]okgo.CheckerParam
 
 	allCheckerConfigs := make(map[okgo.CheckerType]CheckerConfig)
 	// populate default configuration for all checks (contains only excludes)
-	for _, checkerType := range factory.Types() {
+	for _, checkerType := range checkerTypes {
 		allCheckerConfigs[checkerType] = CheckerConfig{
 			Exclude: c.Exclude,
 		}
 	}
 	// populate provided configurations
 	for k, v := range c.Checks {
+		if v.Asset != "" && v.Asset != k && slices.Contains(checkerTypes, k) {
+			return okgo.ProjectParam{}, errors.Errorf("check %q cannot be an alias for %q because a checker of type %q is registered", k, v.Asset, k)
+		}
 		allCheckerConfigs[k] = CheckerConfig(v)
 	}
+	for k, v := range allCheckerConfigs {
+		for _, dependency := range v.DependsOn {
+			if dependency == k {
+				return okgo.ProjectParam{}, errors.Errorf("check %q cannot depend on itself", k)
+			}
+			if _, ok := allCheckerConfigs[dependency]; !ok {
+				return okgo.ProjectParam{}, errors.Errorf("check %q depends on unknown check %q", k, dependency)
+			}
+		}
+	}
 
 	// create parameters
 	if len(allCheckerConfigs) > 0 {
 		checks = make(map[okgo.CheckerType]okgo.CheckerParam)
 		for k, v := range allCheckerConfigs {
-			currParam, err := v.ToParam(k, factory, c.Exclude)
+			currParam, err := v.toParam(k, creator, c.Exclude)
 			if err != nil {
 				return okgo.ProjectParam{}, err
 			}
@@ -61,9 +90,34 @@ func (c *ProjectConfig) ToParam(factory okgo.CheckerFactory) (okgo.ProjectParam,
 type CheckerConfig v0.CheckerConfig
 
 func (c *CheckerConfig) ToParam(checkerType okgo.CheckerType, factory okgo.CheckerFactory, globalExclude matcher.NamesPathsCfg) (okgo.CheckerParam, error) {
-	checker, err := newChecker(checkerType, c.Config, factory)
-	if err != nil {
-		return okgo.CheckerParam{}, err
+	if factory == nil {
+		return okgo.CheckerParam{}, errors.Errorf("factory must be provided")
+	}
+	return c.toParam(checkerType, factory, globalExclude)
+}
+
+func (c *CheckerConfig) toParam(checkerType okgo.CheckerType, creator checkerCreator, globalExclude matcher.NamesPathsCfg) (okgo.CheckerParam, error) {
+	if err := c.verifySharding(); err != nil {
+		return okgo.CheckerParam{}, errors.Wrapf(err, "invalid configuration for check %q", checkerType)
+	}
+	var asset okgo.CheckerType
+	var checker okgo.Checker
+	if c.Asset != "" && c.Asset != checkerType {
+		assetChecker, err := newChecker(c.Asset, c.Config, creator)
+		if err != nil {
+			return okgo.CheckerParam{}, errors.Wrapf(err, "failed to create checker for check %q", checkerType)
+		}
+		asset = c.Asset
+		checker = &aliasChecker{
+			Checker: assetChecker,
+			alias:   checkerType,
+		}
+	} else {
+		newChecker, err := newChecker(checkerType, c.Config, creator)
+		if err != nil {
+			return okgo.CheckerParam{}, err
+		}
+		checker = newChecker
 	}
 	var filters []okgo.Filter
 	for _, filterCfg := range c.Filters {
@@ -73,29 +127,76 @@ func (c *CheckerConfig) ToParam(checkerType okgo.CheckerType, factory okgo.Check
 		}
 		filters = append(filters, currFilter)
 	}
+	var include matcher.Matcher
+	if !c.Include.Empty() {
+		include = c.Include.Matcher()
+	}
 	combinedExcludeConfig := c.Exclude
 	combinedExcludeConfig.Add(globalExclude)
 	return okgo.CheckerParam{
-		Skip:     c.Skip,
-		Priority: (*okgo.CheckerPriority)(c.Priority),
-		Checker:  checker,
-		Filters:  filters,
-		Exclude:  combinedExcludeConfig.Matcher(),
+		Asset:     asset,
+		Skip:      c.Skip,
+		Priority:  (*okgo.CheckerPriority)(c.Priority),
+		Checker:   checker,
+		Filters:   filters,
+		Include:   include,
+		Exclude:   combinedExcludeConfig.Matcher(),
+		Tags:      c.Tags,
+		DependsOn: c.DependsOn,
+		Shards:    c.Shards,
+		ShardSize: c.ShardSize,
 	}, nil
 }
 
-func newChecker(checkerType okgo.CheckerType, cfgYML yaml.MapSlice, factory okgo.CheckerFactory) (okgo.Checker, error) {
+func (c *CheckerConfig) verifySharding() error {
+	if c.Shards < 0 {
+		return errors.Errorf("shards must be non-negative, was %d", c.Shards)
+	}
+	if c.ShardSize < 0 {
+		return errors.Errorf("shard-size must be non-negative, was %d", c.ShardSize)
+	}
+	if c.Shards != 0 && c.ShardSize != 0 {
+		return errors.Errorf("at most one of shards and shard-size may be specified")
+	}
+	return nil
+}
+
+// aliasChecker is a Checker that runs a checker using a different type. It is used for checks that are aliases for
+// another checker.
+type aliasChecker struct {
+	okgo.Checker
+	alias okgo.CheckerType
+}
+
+func (c *aliasChecker) Type() (okgo.CheckerType, error) {
+	return c.alias, nil
+}
+
+func (c *aliasChecker) Resources() (okgo.CheckerResources, error) {
+	if resourceChecker, ok := c.Checker.(okgo.ResourceChecker); ok {
+		return resourceChecker.Resources()
+	}
+	return okgo.CheckerResources{}, nil
+}
+
+func (c *aliasChecker) CheckWithContext(ctx context.Context, pkgPaths []string, projectDir string, stdout io.Writer) {
+	okgo.RunCheck(ctx, c.Checker, pkgPaths, projectDir, stdout)
+}
+
+// checkerCreator creates new checkers. It is satisfied by okgo.CheckerFactory.
+type checkerCreator interface {
+	NewChecker(checkerType okgo.CheckerType, cfgYMLBytes []byte) (okgo.Checker, error)
+}
+
+func newChecker(checkerType okgo.CheckerType, cfgYML yaml.MapSlice, creator checkerCreator) (okgo.Checker, error) {
 	if checkerType == "" {
 		return nil, errors.Errorf("checkerType must be non-empty")
 	}
-	if factory == nil {
-		return nil, errors.Errorf("factory must be provided")
-	}
 	cfgYMLBytes, err := yaml.Marshal(cfgYML)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to marshal configuration")
 	}
-	return factory.NewChecker(checkerType, cfgYMLBytes)
+	return creator.NewChecker(checkerType, cfgYMLBytes)
 }
 
 type FilterConfig v0.FilterConfig
@@ -114,7 +215,7 @@ func (f *FilterConfig) ToFilter() (okgo.Filter, error) {
 func newMessageFilter(input string) (okgo.Filter, error) {
 	msgRegexp, err := regexp.Compile(input)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, errors.Wrapf(err, "invalid message filter %q", input)
 	}
 	return &messageFilterImpl{
 		msgRegexp: msgRegexp,
@@ -0,0 +1,484 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/palantir/okgo/okgo"
+	v0 "github.com/palantir/okgo/okgo/config/internal/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestWithProfile(t *testing.T) {
+	var cfg ProjectConfig
+	err := yaml.Unmarshal([]byte(`
+checks:
+  errcheck:
+    skip: true
+  golint:
+    priority: 3
+profiles:
+  fast:
+    checks:
+      - golint
+      - errcheck
+    overrides:
+      errcheck:
+        priority: 1
+`), &cfg)
+	require.NoError(t, err)
+
+	profileCfg, checks, err := cfg.WithProfile("fast")
+	require.NoError(t, err)
+	assert.Equal(t, []okgo.CheckerType{"golint", "errcheck"}, checks)
+	assert.False(t, profileCfg.Checks["errcheck"].Skip)
+	assert.Equal(t, 1, *profileCfg.Checks["errcheck"].Priority)
+	assert.Equal(t, 3, *profileCfg.Checks["golint"].Priority)
+	// original configuration is not modified
+	assert.True(t, cfg.Checks["errcheck"].Skip)
+
+	_, _, err = cfg.WithProfile("slow")
+	assert.EqualError(t, err, `profile "slow" is not defined (defined profiles: [fast])`)
+}
+
+func TestToParam_Alias(t *testing.T) {
+	var cfg ProjectConfig
+	err := yaml.Unmarshal([]byte(`
+checks:
+  errcheck-tests:
+    asset: errcheck
+    config:
+      strict: false
+`), &cfg)
+	require.NoError(t, err)
+
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"errcheck"}}
+	param, err := cfg.ToParam(factory)
+	require.NoError(t, err)
+	require.Contains(t, param.Checks, okgo.CheckerType("errcheck"))
+	require.Contains(t, param.Checks, okgo.CheckerType("errcheck-tests"))
+
+	aliasParam := param.Checks["errcheck-tests"]
+	assert.Equal(t, okgo.CheckerType("errcheck"), aliasParam.Asset)
+	aliasType, err := aliasParam.Checker.Type()
+	require.NoError(t, err)
+	assert.Equal(t, okgo.CheckerType("errcheck-tests"), aliasType)
+	assert.Equal(t, "strict: false\n", aliasParam.Checker.(*aliasChecker).Checker.(*testChecker).cfgYML)
+}
+
+func TestToParam_AliasForRegisteredTypeFails(t *testing.T) {
+	var cfg ProjectConfig
+	err := yaml.Unmarshal([]byte(`
+checks:
+  golint:
+    asset: errcheck
+`), &cfg)
+	require.NoError(t, err)
+
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"errcheck", "golint"}}
+	_, err = cfg.ToParam(factory)
+	assert.EqualError(t, err, `check "golint" cannot be an alias for "errcheck" because a checker of type "golint" is registered`)
+}
+
+func TestToParam_DependsOnUnknownCheck(t *testing.T) {
+	var cfg ProjectConfig
+	err := yaml.Unmarshal([]byte(`
+checks:
+  vet:
+    depends-on:
+      - compiles
+`), &cfg)
+	require.NoError(t, err)
+
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"vet"}}
+	_, err = cfg.ToParam(factory)
+	assert.EqualError(t, err, `check "vet" depends on unknown check "compiles"`)
+
+	factory.types = append(factory.types, "compiles")
+	param, err := cfg.ToParam(factory)
+	require.NoError(t, err)
+	assert.Equal(t, []okgo.CheckerType{"compiles"}, param.Checks["vet"].DependsOn)
+}
+
+func TestToParam_Sharding(t *testing.T) {
+	var cfg ProjectConfig
+	err := yaml.Unmarshal([]byte(`
+checks:
+  golint:
+    shard-size: 100
+`), &cfg)
+	require.NoError(t, err)
+
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"golint"}}
+	param, err := cfg.ToParam(factory)
+	require.NoError(t, err)
+	assert.Equal(t, 100, param.Checks["golint"].ShardSize)
+
+	checkerCfg := cfg.Checks["golint"]
+	checkerCfg.Shards = 4
+	cfg.Checks["golint"] = checkerCfg
+	_, err = cfg.ToParam(factory)
+	assert.EqualError(t, err, `invalid configuration for check "golint": at most one of shards and shard-size may be specified`)
+}
+
+func TestToParam_ExpandsEnv(t *testing.T) {
+	t.Setenv("OKGO_TEST_CACHE_DIR", "/tmp/cache")
+	t.Setenv("OKGO_TEST_THRESHOLD", "10")
+
+	var cfg ProjectConfig
+	err := yaml.Unmarshal([]byte(`
+checks:
+  errcheck:
+    config:
+      cache-dir: ${OKGO_TEST_CACHE_DIR}/errcheck
+      threshold: ${OKGO_TEST_THRESHOLD}
+      mode: ${OKGO_TEST_UNSET_MODE:-fast}
+      literal: $${OKGO_TEST_THRESHOLD}
+      values:
+        - ${OKGO_TEST_UNSET_VALUE}
+`), &cfg)
+	require.NoError(t, err)
+
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"errcheck"}}
+	param, err := cfg.ToParam(factory)
+	require.NoError(t, err)
+	assert.Equal(t, `cache-dir: /tmp/cache/errcheck
+threshold: 10
+mode: fast
+literal: ${OKGO_TEST_THRESHOLD}
+values:
+- ""
+`, param.Checks["errcheck"].Checker.(*testChecker).cfgYML)
+
+	cfg.FailOnUndefinedEnv = true
+	_, err = cfg.ToParam(factory)
+	assert.EqualError(t, err, `invalid configuration for check "errcheck": invalid config: invalid value for values: environment variable "OKGO_TEST_UNSET_VALUE" is not set`)
+}
+
+func TestToParam_ExpandsEnvKeepsQuotedStrings(t *testing.T) {
+	t.Setenv("OKGO_TEST_THRESHOLD", "10")
+	t.Setenv("OKGO_TEST_MODE", "0755")
+	t.Setenv("OKGO_TEST_ENABLED", "true")
+
+	cfg, err := UnmarshalProjectConfig([]byte(`
+checks:
+  errcheck:
+    config:
+      threshold: ${OKGO_TEST_THRESHOLD}
+      quoted-threshold: "${OKGO_TEST_THRESHOLD}"
+      mode: '${OKGO_TEST_MODE}'
+      enabled: ${OKGO_TEST_ENABLED}
+      tagged-enabled: !!str ${OKGO_TEST_ENABLED}
+      values:
+        - ${OKGO_TEST_THRESHOLD}
+        - "${OKGO_TEST_THRESHOLD}"
+profiles:
+  strict:
+    overrides:
+      errcheck:
+        config:
+          mode: "${OKGO_TEST_MODE}"
+`))
+	require.NoError(t, err)
+
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"errcheck"}}
+	param, err := cfg.ToParam(factory)
+	require.NoError(t, err)
+	// only plain scalars are converted to the type of their expanded value
+	assert.Equal(t, `threshold: 10
+quoted-threshold: "10"
+mode: "0755"
+enabled: true
+tagged-enabled: "true"
+values:
+- 10
+- "10"
+`, param.Checks["errcheck"].Checker.(*testChecker).cfgYML)
+
+	profileCfg, _, err := cfg.WithProfile("strict")
+	require.NoError(t, err)
+	param, err = profileCfg.ToParam(factory)
+	require.NoError(t, err)
+	assert.Equal(t, "mode: \"0755\"\n", param.Checks["errcheck"].Checker.(*testChecker).cfgYML)
+}
+
+func TestToParamWithDirectoryConfigs(t *testing.T) {
+	var cfg ProjectConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+checks:
+  golint:
+    config:
+      strict: false
+`), &cfg))
+	var strictCfg, sameAsRootCfg, errcheckCfg ProjectConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+checks:
+  golint:
+    config:
+      strict: true
+`), &strictCfg))
+	require.NoError(t, yaml.Unmarshal([]byte(`
+checks:
+  golint:
+    config:
+      strict: false
+`), &sameAsRootCfg))
+	require.NoError(t, yaml.Unmarshal([]byte(`
+checks:
+  errcheck:
+    skip: true
+`), &errcheckCfg))
+
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"errcheck", "golint"}}
+	param, err := cfg.ToParamWithDirectoryConfigs(factory, []DirectoryConfig{
+		{Dir: "a", Config: strictCfg},
+		{Dir: "a/c", Config: errcheckCfg},
+		{Dir: "b", Config: strictCfg},
+		{Dir: "d", Config: sameAsRootCfg},
+	})
+	require.NoError(t, err)
+
+	// directories only have parameters for the checks whose configuration differs from the inherited configuration
+	require.Len(t, param.Directories, 3)
+	assert.Equal(t, "a", param.Directories[0].Dir)
+	assert.Equal(t, []okgo.CheckerType{"golint"}, sortedCheckerTypes(param.Directories[0].Checks))
+	assert.Equal(t, "a/c", param.Directories[1].Dir)
+	assert.Equal(t, []okgo.CheckerType{"errcheck"}, sortedCheckerTypes(param.Directories[1].Checks))
+	assert.True(t, param.Directories[1].Checks["errcheck"].Skip)
+	assert.Equal(t, "b", param.Directories[2].Dir)
+	assert.Equal(t, []okgo.CheckerType{"golint"}, sortedCheckerTypes(param.Directories[2].Checks))
+
+	// directories with the same effective configuration share parameters
+	assert.Equal(t, "strict: true\n", param.Directories[0].Checks["golint"].Checker.(*testChecker).cfgYML)
+	assert.Same(t, param.Directories[0].Checks["golint"].Checker, param.Directories[2].Checks["golint"].Checker)
+}
+
+func TestToParamWithDirectoryConfigs_RelativePaths(t *testing.T) {
+	var cfg, dirCfg ProjectConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+exclude:
+  paths:
+    - gen
+`), &cfg))
+	require.NoError(t, yaml.Unmarshal([]byte(`
+exclude:
+  names:
+    - mocks
+  paths:
+    - generated
+checks:
+  golint:
+    include:
+      paths:
+        - api
+    exclude:
+      paths:
+        - api/internal
+`), &dirCfg))
+
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"errcheck", "golint"}}
+	param, err := cfg.ToParamWithDirectoryConfigs(factory, []DirectoryConfig{
+		{Dir: "a", Config: dirCfg},
+	})
+	require.NoError(t, err)
+	require.Len(t, param.Directories, 1)
+
+	// paths in the directory configuration are relative to the directory, while inherited paths are unchanged
+	errcheckParam := param.Directories[0].Checks["errcheck"]
+	for _, excluded := range []string{"gen", "a/generated", "a/b/mocks"} {
+		assert.True(t, errcheckParam.Exclude.Match(excluded), excluded)
+	}
+	for _, notExcluded := range []string{"generated", "a/gen"} {
+		assert.False(t, errcheckParam.Exclude.Match(notExcluded), notExcluded)
+	}
+
+	golintParam := param.Directories[0].Checks["golint"]
+	assert.True(t, golintParam.Include.Match("a/api"))
+	assert.False(t, golintParam.Include.Match("api"))
+	assert.True(t, golintParam.Exclude.Match("a/api/internal"))
+	assert.False(t, golintParam.Exclude.Match("api/internal"))
+}
+
+func sortedCheckerTypes(checks map[okgo.CheckerType]okgo.CheckerParam) []okgo.CheckerType {
+	var checkerTypes []okgo.CheckerType
+	for k := range checks {
+		checkerTypes = append(checkerTypes, k)
+	}
+	sort.Sort(okgo.ByCheckerType(checkerTypes))
+	return checkerTypes
+}
+
+func TestValidate(t *testing.T) {
+	cfgYML := `checks:
+  errcheck:
+    config:
+      mode: invalid
+    filters:
+      - value: "(unclosed"
+  unknown:
+    skip: true
+  errcheck-tests:
+    asset: missing
+exclude:
+  names:
+    - "[a-"
+  foo: bar
+`
+	factory := &testCheckerFactory{types: []okgo.CheckerType{"errcheck"}}
+	issues := Validate("check-plugin.yml", []byte(cfgYML), factory)
+	var issueStrs []string
+	for _, issue := range issues {
+		issueStrs = append(issueStrs, issue.String())
+	}
+	assert.Equal(t, []string{
+		`check-plugin.yml:14:3: unknown field "foo" (valid fields: [names paths])`,
+	}, issueStrs)
+
+	cfgYML = strings.Replace(cfgYML, "  foo: bar\n", "", 1)
+	issues = Validate("check-plugin.yml", []byte(cfgYML), factory)
+	issueStrs = nil
+	for _, issue := range issues {
+		issueStrs = append(issueStrs, issue.String())
+	}
+	assert.Equal(t, []string{
+		`check-plugin.yml:3:5: invalid configuration for check "errcheck": invalid configuration`,
+		"check-plugin.yml:6:16: invalid regular expression \"(unclosed\": error parsing regexp: missing closing ): `(unclosed`",
+		`check-plugin.yml:7:3: unknown check "unknown" (registered checks: [errcheck])`,
+		`check-plugin.yml:10:12: unknown asset "missing" for check "errcheck-tests" (registered checks: [errcheck])`,
+		"check-plugin.yml:13:7: invalid regular expression \"[a-\": error parsing regexp: missing closing ]: `[a-`",
+	}, issueStrs)
+}
+
+func TestJSONSchema(t *testing.T) {
+	factory := &testCheckerFactory{
+		types: []okgo.CheckerType{"errcheck", "golint"},
+		configSchemas: map[okgo.CheckerType]json.RawMessage{
+			"errcheck": json.RawMessage(`{"type":"object","properties":{"ignore":{"type":"string"}}}`),
+		},
+	}
+	schemaBytes, err := JSONSchema(factory)
+	require.NoError(t, err)
+
+	var schema struct {
+		Properties  map[string]json.RawMessage `json:"properties"`
+		Definitions map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+			AllOf      []json.RawMessage          `json:"allOf"`
+		} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(schemaBytes, &schema))
+	assert.Contains(t, schema.Properties, "checks")
+	assert.Contains(t, schema.Properties, "profiles")
+	assert.Equal(t, `{"type":"object","properties":{"ignore":{"type":"string"}}}`, compactJSON(t, schema.Definitions["check-errcheck"].Properties["config"]))
+	assert.Equal(t, `{"description":"The configuration for the checker.","type":"object"}`, compactJSON(t, schema.Definitions["check-golint"].Properties["config"]))
+	assert.Equal(t, `{"const":"errcheck","description":"The type of the checker that provides this check."}`, compactJSON(t, schema.Definitions["check-errcheck"].Properties["asset"]))
+	assert.Equal(t, `{"description":"The type of the checker that provides this check.","enum":["errcheck","golint"]}`, compactJSON(t, schema.Definitions["alias"].Properties["asset"]))
+	assert.Len(t, schema.Definitions["alias"].AllOf, 2)
+
+	// every field accepted by the configuration is in the schema and has a description
+	for _, definitionName := range []string{"check-errcheck", "check-golint", "alias"} {
+		checkProperties := schema.Definitions[definitionName].Properties
+		checkerConfigType := reflect.TypeOf(v0.CheckerConfig{})
+		require.Len(t, checkProperties, checkerConfigType.NumField(), definitionName)
+		for i := 0; i < checkerConfigType.NumField(); i++ {
+			name := strings.Split(checkerConfigType.Field(i).Tag.Get("yaml"), ",")[0]
+			if assert.Contains(t, checkProperties, name, definitionName) && name != "config" {
+				assert.Contains(t, string(checkProperties[name]), `"description"`, "%s.%s", definitionName, name)
+			}
+		}
+	}
+	projectConfigType := reflect.TypeOf(v0.ProjectConfig{})
+	require.Len(t, schema.Properties, projectConfigType.NumField())
+	for i := 0; i < projectConfigType.NumField(); i++ {
+		name := strings.Split(projectConfigType.Field(i).Tag.Get("yaml"), ",")[0]
+		if assert.Contains(t, schema.Properties, name) {
+			assert.Contains(t, string(schema.Properties[name]), `"description"`, name)
+		}
+	}
+}
+
+func TestJSONSchema_FactoryWithoutConfigSchemas(t *testing.T) {
+	schemaBytes, err := JSONSchema(&typesCheckerFactory{types: []okgo.CheckerType{"errcheck"}})
+	require.NoError(t, err)
+
+	var schema struct {
+		Definitions map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(schemaBytes, &schema))
+	assert.Equal(t, `{"description":"The configuration for the checker.","type":"object"}`, compactJSON(t, schema.Definitions["check-errcheck"].Properties["config"]))
+}
+
+type typesCheckerFactory struct {
+	okgo.CheckerFactory
+	types []okgo.CheckerType
+}
+
+func (f *typesCheckerFactory) Types() []okgo.CheckerType {
+	return f.types
+}
+
+func compactJSON(t *testing.T, in json.RawMessage) string {
+	var buf bytes.Buffer
+	require.NoError(t, json.Compact(&buf, in))
+	return buf.String()
+}
+
+type testCheckerFactory struct {
+	okgo.CheckerFactory
+	types         []okgo.CheckerType
+	configSchemas map[okgo.CheckerType]json.RawMessage
+}
+
+func (f *testCheckerFactory) Types() []okgo.CheckerType {
+	return f.types
+}
+
+func (f *testCheckerFactory) ConfigSchema(checkerType okgo.CheckerType) (json.RawMessage, error) {
+	return f.configSchemas[checkerType], nil
+}
+
+func (f *testCheckerFactory) NewChecker(checkerType okgo.CheckerType, cfgYMLBytes []byte) (okgo.Checker, error) {
+	if strings.Contains(string(cfgYMLBytes), "invalid") {
+		return nil, errors.New("invalid configuration")
+	}
+	return &testChecker{
+		checkerType: checkerType,
+		cfgYML:      string(cfgYMLBytes),
+	}, nil
+}
+
+type testChecker struct {
+	okgo.Checker
+	checkerType okgo.CheckerType
+	cfgYML      string
+}
+
+func (c *testChecker) Type() (okgo.CheckerType, error) {
+	return c.checkerType, nil
+}
+
+func (c *testChecker) Check(pkgPaths []string, projectDir string, stdout io.Writer) {}
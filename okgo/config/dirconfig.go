@@ -0,0 +1,254 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/palantir/okgo/okgo"
+	v0 "github.com/palantir/okgo/okgo/config/internal/v0"
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// DirectoryConfigFileName is the name of the configuration file that can be placed in a subdirectory of a project to
+// override the configuration for the packages in that directory and its subdirectories.
+const DirectoryConfigFileName = ".okgo.yml"
+
+// DirectoryConfig is configuration that applies to the packages in a specific directory and its subdirectories.
+type DirectoryConfig struct {
+	// Dir is the slash-separated path to the directory relative to the project directory.
+	Dir string
+
+	// Config is the configuration for the directory. A check entry in Config replaces the entry for the same check
+	// inherited from the parent directory in its entirety, while Exclude is added to the inherited excludes. The paths
+	// of the include and exclude criteria in Config are relative to Dir.
+	Config ProjectConfig
+}
+
+// DirectoryConfigFiles returns the slash-separated paths (relative to projectDir) of all of the directory
+// configuration files in the subdirectories of projectDir. Directories that match the provided exclude matcher are not
+// searched. The returned paths are sorted.
+func DirectoryConfigFiles(projectDir string, exclude matcher.Matcher) ([]string, error) {
+	var cfgFiles []string
+	if err := filepath.Walk(projectDir, func(currPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(projectDir, currPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to determine relative path")
+		}
+		relPath = filepath.ToSlash(relPath)
+		if info.IsDir() {
+			if relPath != "." && exclude != nil && exclude.Match(relPath) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Name() == DirectoryConfigFileName && path.Dir(relPath) != "." {
+			cfgFiles = append(cfgFiles, relPath)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to search for %s files in %s", DirectoryConfigFileName, projectDir)
+	}
+	sort.Strings(cfgFiles)
+	return cfgFiles, nil
+}
+
+// ToParamWithDirectoryConfigs returns the parameters for the project where the provided directory configurations are
+// applied on top of this configuration. The Dir of each directory configuration is used as-is for the Dir of the
+// corresponding okgo.DirectoryParam, so it should be expressed relative to the working directory. A directory only has
+// parameters for the checks whose effective configuration differs from the configuration it inherits, and directories
+// whose effective configuration for a check is the same share the same parameters for it.
+func (c *ProjectConfig) ToParamWithDirectoryConfigs(factory okgo.CheckerFactory, dirCfgs []DirectoryConfig) (okgo.ProjectParam, error) {
+	checkerCreator := newCachingCheckerCreator(factory)
+	projectParam, err := c.toParam(factory.Types(), checkerCreator)
+	if err != nil {
+		return okgo.ProjectParam{}, err
+	}
+
+	// params caches the parameters for each check based on its effective configuration
+	params := make(map[string]okgo.CheckerParam)
+	for k, v := range projectParam.Checks {
+		key, err := c.checkConfigKey(k)
+		if err != nil {
+			return okgo.ProjectParam{}, err
+		}
+		params[key] = v
+	}
+
+	sortedDirCfgs := append([]DirectoryConfig(nil), dirCfgs...)
+	for i := range sortedDirCfgs {
+		sortedDirCfgs[i].Dir = path.Clean(sortedDirCfgs[i].Dir)
+	}
+	// sorting ensures that parent directories are processed before their subdirectories
+	sort.SliceStable(sortedDirCfgs, func(i, j int) bool {
+		return sortedDirCfgs[i].Dir < sortedDirCfgs[j].Dir
+	})
+
+	effectiveCfgs := make(map[string]ProjectConfig)
+	for _, dirCfg := range sortedDirCfgs {
+		parentCfg := *c
+		for parentDir := path.Dir(dirCfg.Dir); ; parentDir = path.Dir(parentDir) {
+			if cfg, ok := effectiveCfgs[parentDir]; ok {
+				parentCfg = cfg
+				break
+			}
+			if parentDir == "." || parentDir == "/" || path.Base(parentDir) == ".." {
+				break
+			}
+		}
+		effectiveCfg := parentCfg.withDirectoryConfig(dirCfg.Config.rebasedOnto(dirCfg.Dir))
+		effectiveCfgs[dirCfg.Dir] = effectiveCfg
+
+		dirParam, err := effectiveCfg.toParam(factory.Types(), checkerCreator)
+		if err != nil {
+			return okgo.ProjectParam{}, errors.Wrapf(err, "invalid configuration for directory %s", dirCfg.Dir)
+		}
+		checks := make(map[okgo.CheckerType]okgo.CheckerParam)
+		for k, v := range dirParam.Checks {
+			if _, ok := projectParam.Checks[k]; !ok {
+				return okgo.ProjectParam{}, errors.Errorf("check %q is configured for directory %s but is not defined in the project configuration", k, dirCfg.Dir)
+			}
+			key, err := effectiveCfg.checkConfigKey(k)
+			if err != nil {
+				return okgo.ProjectParam{}, err
+			}
+			parentKey, err := parentCfg.checkConfigKey(k)
+			if err != nil {
+				return okgo.ProjectParam{}, err
+			}
+			if key == parentKey {
+				// packages in the directory use the parameters inherited from the parent directory
+				continue
+			}
+			if cached, ok := params[key]; ok {
+				v = cached
+			} else {
+				params[key] = v
+			}
+			checks[k] = v
+		}
+		if len(checks) == 0 {
+			continue
+		}
+		projectParam.Directories = append(projectParam.Directories, okgo.DirectoryParam{
+			Dir:    dirCfg.Dir,
+			Checks: checks,
+		})
+	}
+	return projectParam, nil
+}
+
+// checkConfigKey returns a key for the effective configuration of the provided check in this configuration. The
+// parameters for a check are the same for configurations that have the same key for it.
+func (c *ProjectConfig) checkConfigKey(checkerType okgo.CheckerType) (string, error) {
+	keyBytes, err := yaml.Marshal(struct {
+		Check              v0.CheckerConfig      `yaml:"check"`
+		Exclude            matcher.NamesPathsCfg `yaml:"exclude"`
+		FailOnUndefinedEnv bool                  `yaml:"fail-on-undefined-env"`
+	}{
+		Check:              c.Checks[checkerType],
+		Exclude:            c.Exclude,
+		FailOnUndefinedEnv: c.FailOnUndefinedEnv,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal configuration for check %q", checkerType)
+	}
+	return string(checkerType) + "\x00" + string(keyBytes), nil
+}
+
+// withDirectoryConfig returns a copy of this configuration with the provided directory configuration applied to it.
+func (c ProjectConfig) withDirectoryConfig(dirCfg ProjectConfig) ProjectConfig {
+	merged := ProjectConfig{
+		Checks:             make(map[okgo.CheckerType]v0.CheckerConfig),
+		FailOnUndefinedEnv: c.FailOnUndefinedEnv || dirCfg.FailOnUndefinedEnv,
+	}
+	for k, v := range c.Checks {
+		merged.Checks[k] = v
+	}
+	for k, v := range dirCfg.Checks {
+		merged.Checks[k] = v
+	}
+	merged.Exclude.Add(c.Exclude)
+	merged.Exclude.Add(dirCfg.Exclude)
+	return merged
+}
+
+// rebasedOnto returns a copy of this configuration in which the paths of the include and exclude criteria, which are
+// relative to the provided directory, are joined with the directory. Names are not changed because they match files
+// and directories in any location.
+func (c ProjectConfig) rebasedOnto(dir string) ProjectConfig {
+	rebased := c
+	rebased.Exclude = rebaseNamesPathsCfg(c.Exclude, dir)
+	if c.Checks != nil {
+		rebased.Checks = make(map[okgo.CheckerType]v0.CheckerConfig, len(c.Checks))
+		for k, v := range c.Checks {
+			v.Include = rebaseNamesPathsCfg(v.Include, dir)
+			v.Exclude = rebaseNamesPathsCfg(v.Exclude, dir)
+			rebased.Checks[k] = v
+		}
+	}
+	return rebased
+}
+
+func rebaseNamesPathsCfg(cfg matcher.NamesPathsCfg, dir string) matcher.NamesPathsCfg {
+	if len(cfg.Paths) == 0 {
+		return cfg
+	}
+	paths := make([]string, len(cfg.Paths))
+	for i, p := range cfg.Paths {
+		paths[i] = path.Join(dir, p)
+	}
+	return matcher.NamesPathsCfg{
+		Names: cfg.Names,
+		Paths: paths,
+	}
+}
+
+// cachingCheckerCreator creates checkers using a factory and caches the result based on the checker type and its
+// configuration. Directory configurations typically only change a small number of checks, and creating checkers can be
+// expensive (for assets, it requires verifying the configuration using a separate process).
+type cachingCheckerCreator struct {
+	factory  okgo.CheckerFactory
+	checkers map[string]okgo.Checker
+}
+
+func newCachingCheckerCreator(factory okgo.CheckerFactory) *cachingCheckerCreator {
+	return &cachingCheckerCreator{
+		factory:  factory,
+		checkers: make(map[string]okgo.Checker),
+	}
+}
+
+func (c *cachingCheckerCreator) NewChecker(checkerType okgo.CheckerType, cfgYMLBytes []byte) (okgo.Checker, error) {
+	key := strings.Join([]string{string(checkerType), string(cfgYMLBytes)}, "\x00")
+	if checker, ok := c.checkers[key]; ok {
+		return checker, nil
+	}
+	checker, err := c.factory.NewChecker(checkerType, cfgYMLBytes)
+	if err != nil {
+		return nil, err
+	}
+	c.checkers[key] = checker
+	return checker, nil
+}
@@ -0,0 +1,325 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/palantir/okgo/okgo"
+	v0 "github.com/palantir/okgo/okgo/config/internal/v0"
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+	yamlv3 "go.yaml.in/yaml/v3"
+	"gopkg.in/yaml.v2"
+)
+
+var envVarRegexp = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// envExpander expands references to environment variables of the form "${VAR}" and "${VAR:-default}" in strings. The
+// default value is used if the variable is unset or empty. The sequence "$${" is expanded to a literal "${".
+type envExpander struct {
+	lookupEnv func(string) (string, bool)
+	// failOnUndefined specifies whether referencing a variable that is not set and does not have a default value is an
+	// error. If false, such references expand to the empty string.
+	failOnUndefined bool
+}
+
+func newEnvExpander(failOnUndefined bool) *envExpander {
+	return &envExpander{
+		lookupEnv:       os.LookupEnv,
+		failOnUndefined: failOnUndefined,
+	}
+}
+
+func (e *envExpander) expandString(in string) (string, error) {
+	var rErr error
+	out := envVarRegexp.ReplaceAllStringFunc(in, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+		submatches := envVarRegexp.FindStringSubmatch(match)
+		name, hasDefault, defaultVal := submatches[1], submatches[2] != "", submatches[3]
+		val, ok := e.lookupEnv(name)
+		if ok && val != "" {
+			return val
+		}
+		if hasDefault {
+			return defaultVal
+		}
+		if !ok && e.failOnUndefined && rErr == nil {
+			rErr = errors.Errorf("environment variable %q is not set", name)
+		}
+		return val
+	})
+	if rErr != nil {
+		return "", rErr
+	}
+	return out, nil
+}
+
+func (e *envExpander) expandStrings(in []string) ([]string, error) {
+	if in == nil {
+		return nil, nil
+	}
+	out := make([]string, len(in))
+	for i, curr := range in {
+		expanded, err := e.expandString(curr)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = expanded
+	}
+	return out, nil
+}
+
+func (e *envExpander) expandNamesPaths(in matcher.NamesPathsCfg) (matcher.NamesPathsCfg, error) {
+	names, err := e.expandStrings(in.Names)
+	if err != nil {
+		return matcher.NamesPathsCfg{}, err
+	}
+	paths, err := e.expandStrings(in.Paths)
+	if err != nil {
+		return matcher.NamesPathsCfg{}, err
+	}
+	return matcher.NamesPathsCfg{
+		Names: names,
+		Paths: paths,
+	}, nil
+}
+
+// quotedString is a string value in the "config" block of a check that was specified using a quoted (or explicitly
+// tagged) YAML scalar and that contains a variable reference. Such values always expand to strings.
+type quotedString string
+
+// expandYAML returns a copy of the provided YAML value with all of the string values expanded. Keys of mappings are not
+// expanded. If a string that contains a variable reference expands to a YAML boolean or number (for example,
+// "${THRESHOLD}" expanding to "10"), the expanded value is the boolean or number rather than a string unless the
+// string was specified using a quoted scalar (see markQuotedStrings).
+func (e *envExpander) expandYAML(in interface{}) (interface{}, error) {
+	switch v := in.(type) {
+	case quotedString:
+		return e.expandString(string(v))
+	case string:
+		expanded, err := e.expandString(v)
+		if err != nil {
+			return nil, err
+		}
+		if expanded == v {
+			return v, nil
+		}
+		return typedScalar(expanded), nil
+	case yaml.MapSlice:
+		if v == nil {
+			return v, nil
+		}
+		out := make(yaml.MapSlice, len(v))
+		for i, item := range v {
+			expanded, err := e.expandYAML(item.Value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value for %v", item.Key)
+			}
+			out[i] = yaml.MapItem{Key: item.Key, Value: expanded}
+		}
+		return out, nil
+	case map[interface{}]interface{}:
+		out := make(map[interface{}]interface{}, len(v))
+		for k, val := range v {
+			expanded, err := e.expandYAML(val)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value for %v", k)
+			}
+			out[k] = expanded
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			expanded, err := e.expandYAML(val)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = expanded
+		}
+		return out, nil
+	default:
+		return in, nil
+	}
+}
+
+func typedScalar(in string) interface{} {
+	var out interface{}
+	if err := yaml.Unmarshal([]byte(in), &out); err != nil {
+		return in
+	}
+	switch out.(type) {
+	case bool:
+		// YAML 1.1 also treats values such as "yes" and "on" as booleans: only convert the canonical forms
+		if in == "true" || in == "false" {
+			return out
+		}
+		return in
+	case int, int64, uint64, float64:
+		return out
+	default:
+		return in
+	}
+}
+
+// markQuotedStrings returns a copy of the provided YAML value, which must be the result of decoding the provided node,
+// where the strings that contain a variable reference and that were specified using a scalar that is not plain (quoted,
+// a block scalar or explicitly tagged) are quotedString values. This preserves the information that is otherwise lost
+// when YAML is decoded into a yaml.MapSlice, so that "${MODE}" expands to the string "0755" rather than the number 493.
+func markQuotedStrings(in interface{}, node *yamlv3.Node) interface{} {
+	if node == nil {
+		return in
+	}
+	if node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch v := in.(type) {
+	case string:
+		if node.Kind == yamlv3.ScalarNode && node.Style != 0 && strings.Contains(v, "${") {
+			return quotedString(v)
+		}
+		return v
+	case yaml.MapSlice:
+		if v == nil {
+			return v
+		}
+		out := make(yaml.MapSlice, len(v))
+		for i, item := range v {
+			out[i] = yaml.MapItem{Key: item.Key, Value: markQuotedStrings(item.Value, mappingValueForKey(node, item.Key))}
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := make(map[interface{}]interface{}, len(v))
+		for k, val := range v {
+			out[k] = markQuotedStrings(val, mappingValueForKey(node, k))
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			var itemNode *yamlv3.Node
+			if node.Kind == yamlv3.SequenceNode && len(node.Content) == len(v) {
+				itemNode = node.Content[i]
+			}
+			out[i] = markQuotedStrings(val, itemNode)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+// mappingValueForKey returns the value node for the provided decoded key in the provided mapping node. Returns nil if
+// the node is not a mapping or does not contain the key.
+func mappingValueForKey(node *yamlv3.Node, key interface{}) *yamlv3.Node {
+	_, valNode := mappingValue(node, fmt.Sprint(key))
+	return valNode
+}
+
+// markQuotedChecks marks the quoted strings in the "config" blocks of the provided checks, which must be the result of
+// decoding the provided mapping node of checks.
+func markQuotedChecks(checks map[okgo.CheckerType]v0.CheckerConfig, checksNode *yamlv3.Node) {
+	for k, v := range checks {
+		_, checkNode := mappingValue(checksNode, string(k))
+		_, configNode := mappingValue(checkNode, "config")
+		if configNode == nil {
+			continue
+		}
+		v.Config = markQuotedStrings(v.Config, configNode).(yaml.MapSlice)
+		checks[k] = v
+	}
+}
+
+// UnmarshalProjectConfig unmarshals the provided YAML as a ProjectConfig. Unlike unmarshalling the YAML directly, the
+// configuration retains whether the values that reference environment variables were specified using quoted scalars,
+// so that quoted values always expand to strings.
+func UnmarshalProjectConfig(cfgBytes []byte) (ProjectConfig, error) {
+	var cfg ProjectConfig
+	if err := yaml.Unmarshal(cfgBytes, &cfg); err != nil {
+		return ProjectConfig{}, errors.Wrapf(err, "failed to unmarshal configuration")
+	}
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(cfgBytes, &doc); err != nil || len(doc.Content) == 0 {
+		return cfg, nil
+	}
+	root := doc.Content[0]
+	_, checksNode := mappingValue(root, "checks")
+	markQuotedChecks(cfg.Checks, checksNode)
+	_, profilesNode := mappingValue(root, "profiles")
+	for name, profile := range cfg.Profiles {
+		_, profileNode := mappingValue(profilesNode, name)
+		_, overridesNode := mappingValue(profileNode, "overrides")
+		markQuotedChecks(profile.Overrides, overridesNode)
+	}
+	return cfg, nil
+}
+
+// expandEnv returns a copy of this configuration with references to environment variables expanded in all of its
+// string values.
+func (c *ProjectConfig) expandEnv(e *envExpander) (ProjectConfig, error) {
+	expanded := *c
+	exclude, err := e.expandNamesPaths(c.Exclude)
+	if err != nil {
+		return ProjectConfig{}, errors.Wrapf(err, "invalid exclude configuration")
+	}
+	expanded.Exclude = exclude
+
+	if c.Checks != nil {
+		expanded.Checks = make(map[okgo.CheckerType]v0.CheckerConfig, len(c.Checks))
+		for k, v := range c.Checks {
+			expandedCheckerCfg, err := (*CheckerConfig)(&v).expandEnv(e)
+			if err != nil {
+				return ProjectConfig{}, errors.Wrapf(err, "invalid configuration for check %q", k)
+			}
+			expanded.Checks[k] = v0.CheckerConfig(expandedCheckerCfg)
+		}
+	}
+	return expanded, nil
+}
+
+func (c *CheckerConfig) expandEnv(e *envExpander) (CheckerConfig, error) {
+	expanded := *c
+
+	cfgYML, err := e.expandYAML(c.Config)
+	if err != nil {
+		return CheckerConfig{}, errors.Wrapf(err, "invalid config")
+	}
+	expanded.Config = cfgYML.(yaml.MapSlice)
+
+	if c.Filters != nil {
+		expanded.Filters = make([]v0.FilterConfig, len(c.Filters))
+		for i, filter := range c.Filters {
+			val, err := e.expandString(filter.Value)
+			if err != nil {
+				return CheckerConfig{}, errors.Wrapf(err, "invalid filter")
+			}
+			filter.Value = val
+			expanded.Filters[i] = filter
+		}
+	}
+	if expanded.Include, err = e.expandNamesPaths(c.Include); err != nil {
+		return CheckerConfig{}, errors.Wrapf(err, "invalid include configuration")
+	}
+	if expanded.Exclude, err = e.expandNamesPaths(c.Exclude); err != nil {
+		return CheckerConfig{}, errors.Wrapf(err, "invalid exclude configuration")
+	}
+	return expanded, nil
+}
@@ -31,9 +31,32 @@ type ProjectConfig struct {
 
 	// Exclude specifies the paths that should be excluded from all checks.
 	Exclude matcher.NamesPathsCfg `yaml:"exclude,omitempty"`
+
+	// FailOnUndefinedEnv specifies whether it is an error for the configuration to reference an environment variable
+	// that is not set. String values in the configuration can reference environment variables using "${VAR}" or
+	// "${VAR:-default}". If this value is false, a reference to a variable that is not set expands to the empty string.
+	FailOnUndefinedEnv bool `yaml:"fail-on-undefined-env,omitempty"`
+
+	// Profiles specifies named sets of checks and configuration that can be selected when running checks. The key is
+	// the name of the profile.
+	Profiles map[string]ProfileConfig `yaml:"profiles,omitempty"`
+}
+
+type ProfileConfig struct {
+	// Checks specifies the checks that are run when the profile is selected. If empty, all checks are run.
+	Checks []okgo.CheckerType `yaml:"checks,omitempty"`
+
+	// Overrides specifies the configuration for checks that is used when the profile is selected. An entry replaces the
+	// configuration for the same check in the top-level "checks" configuration in its entirety.
+	Overrides map[okgo.CheckerType]CheckerConfig `yaml:"overrides,omitempty"`
 }
 
 type CheckerConfig struct {
+	// Asset specifies the type of the checker that provides this check. If non-empty, the key for this configuration
+	// is an alias that defines a new check that uses the specified checker. This allows the same checker to be run
+	// multiple times using different configuration.
+	Asset okgo.CheckerType `yaml:"asset,omitempty"`
+
 	// Skip indicates whether or not the check should be skipped entirely.
 	Skip bool `yaml:"skip,omitempty"`
 
@@ -48,8 +71,28 @@ type CheckerConfig struct {
 	// processing.
 	Filters []FilterConfig `yaml:"filters,omitempty"`
 
+	// Include specifies the paths that this check should be restricted to. If non-empty, the check is only run on the
+	// packages that match (or that contain a file that matches) and issues are only reported for paths that match. The
+	// include criteria is applied before Exclude.
+	Include matcher.NamesPathsCfg `yaml:"include,omitempty"`
+
 	// Exclude specifies the paths that should be excluded from this check.
 	Exclude matcher.NamesPathsCfg `yaml:"exclude,omitempty"`
+
+	// Tags specifies tags for this check in addition to the tags advertised by the checker.
+	Tags []string `yaml:"tags,omitempty"`
+
+	// DependsOn specifies the checks that must pass before this check is run. If any of the checks fail, this check is
+	// not run.
+	DependsOn []okgo.CheckerType `yaml:"depends-on,omitempty"`
+
+	// Shards specifies the number of invocations of the check that the packages are split across. The invocations run
+	// concurrently and the issues that they produce are merged. At most one of Shards and ShardSize may be specified.
+	Shards int `yaml:"shards,omitempty"`
+
+	// ShardSize specifies the maximum number of packages checked by a single invocation of the check. If non-zero, the
+	// packages are split across as many concurrent invocations as necessary.
+	ShardSize int `yaml:"shard-size,omitempty"`
 }
 
 type FilterConfig struct {
@@ -88,19 +131,47 @@ func UpgradeConfig(cfgBytes []byte, factory okgo.CheckerFactory) ([]byte, error)
 // upgradeAssets upgrades the assets for the provided configuration. Returns true if any upgrade operations were
 // performed. If any upgrade operations were performed, the provided configuration is modified directly.
 func upgradeAssets(cfg *ProjectConfig, factory okgo.CheckerFactory) (changed bool, rErr error) {
+	changed, err := upgradeCheckerConfigs(cfg.Checks, factory)
+	if err != nil {
+		return false, err
+	}
+
+	var sortedProfiles []string
+	for k := range cfg.Profiles {
+		sortedProfiles = append(sortedProfiles, k)
+	}
+	sort.Strings(sortedProfiles)
+
+	for _, profile := range sortedProfiles {
+		profileChanged, err := upgradeCheckerConfigs(cfg.Profiles[profile].Overrides, factory)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to upgrade profile %q", profile)
+		}
+		changed = changed || profileChanged
+	}
+	return changed, nil
+}
+
+// upgradeCheckerConfigs upgrades the asset configuration of the provided checks. Returns true if any upgrade operations
+// were performed. If any upgrade operations were performed, the provided map is modified directly.
+func upgradeCheckerConfigs(checks map[okgo.CheckerType]CheckerConfig, factory okgo.CheckerFactory) (changed bool, rErr error) {
 	var sortedKeys []okgo.CheckerType
-	for k := range cfg.Checks {
+	for k := range checks {
 		sortedKeys = append(sortedKeys, k)
 	}
 	sort.Sort(okgo.ByCheckerType(sortedKeys))
 
 	for _, k := range sortedKeys {
-		upgrader, err := factory.ConfigUpgrader(k)
+		assetType := k
+		if checks[k].Asset != "" {
+			assetType = checks[k].Asset
+		}
+		upgrader, err := factory.ConfigUpgrader(assetType)
 		if err != nil {
 			return false, err
 		}
 
-		assetCfgBytes, err := yaml.Marshal(cfg.Checks[k].Config)
+		assetCfgBytes, err := yaml.Marshal(checks[k].Config)
 		if err != nil {
 			return false, errors.Wrapf(err, "failed to marshal check %q configuration", k)
 		}
@@ -122,9 +193,9 @@ func upgradeAssets(cfg *ProjectConfig, factory okgo.CheckerFactory) (changed boo
 		}
 
 		// update configuration for asset in original configuration
-		assetCheckCfg := cfg.Checks[k]
+		assetCheckCfg := checks[k]
 		assetCheckCfg.Config = yamlRep
-		cfg.Checks[k] = assetCheckCfg
+		checks[k] = assetCheckCfg
 	}
 	return changed, nil
 }
@@ -0,0 +1,50 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sort"
+
+	"github.com/palantir/okgo/okgo"
+	v0 "github.com/palantir/okgo/okgo/config/internal/v0"
+	"github.com/pkg/errors"
+)
+
+type ProfileConfig v0.ProfileConfig
+
+// WithProfile returns a copy of this configuration with the overrides of the specified profile applied and the checks
+// that the profile specifies should be run. If the profile does not specify any checks, the returned slice is empty.
+// Returns an error if the profile is not defined.
+func (c *ProjectConfig) WithProfile(profile string) (ProjectConfig, []okgo.CheckerType, error) {
+	profileCfg, ok := c.Profiles[profile]
+	if !ok {
+		var profiles []string
+		for k := range c.Profiles {
+			profiles = append(profiles, k)
+		}
+		sort.Strings(profiles)
+		return ProjectConfig{}, nil, errors.Errorf("profile %q is not defined (defined profiles: %v)", profile, profiles)
+	}
+
+	profileProjectCfg := *c
+	profileProjectCfg.Checks = make(map[okgo.CheckerType]v0.CheckerConfig)
+	for k, v := range c.Checks {
+		profileProjectCfg.Checks[k] = v
+	}
+	for k, v := range profileCfg.Overrides {
+		profileProjectCfg.Checks[k] = v
+	}
+	return profileProjectCfg, append([]okgo.CheckerType(nil), profileCfg.Checks...), nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/palantir/okgo/okgo"
+	v0 "github.com/palantir/okgo/okgo/config/internal/v0"
+	"github.com/palantir/pkg/matcher"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+type jsonSchema map[string]interface{}
+
+// fieldDescriptions are the descriptions of the fields of the configuration types, keyed by the name of the type and
+// the YAML key of the field.
+var fieldDescriptions = map[string]string{
+	"ProjectConfig.checks":                "The configuration for the checks.",
+	"ProjectConfig.exclude":               "The paths that are excluded from all checks.",
+	"ProjectConfig.fail-on-undefined-env": "Whether referencing an environment variable that is not set is an error.",
+	"ProjectConfig.profiles":              "Named sets of checks and configuration that can be selected when running checks.",
+	"ProfileConfig.checks":                "The checks that are run when the profile is selected.",
+	"ProfileConfig.overrides":             "Configuration that replaces the configuration for the same check when the profile is selected.",
+	"CheckerConfig.asset":                 "The type of the checker that provides this check.",
+	"CheckerConfig.skip":                  "Whether the check is skipped.",
+	"CheckerConfig.priority":              "The priority of the check. Overrides the priority provided by the checker.",
+	"CheckerConfig.config":                "The configuration for the checker.",
+	"CheckerConfig.filters":               "Filters for the output of the check. Output that matches a filter is not reported.",
+	"CheckerConfig.include":               "The paths that the check is restricted to.",
+	"CheckerConfig.exclude":               "The paths that are excluded from the check.",
+	"CheckerConfig.tags":                  "Tags for the check in addition to the tags provided by the checker.",
+	"CheckerConfig.depends-on":            "The checks that must pass before the check is run.",
+	"CheckerConfig.shards":                "The number of concurrent invocations of the check that the packages are split across.",
+	"CheckerConfig.shard-size":            "The maximum number of packages checked by a single invocation of the check.",
+	"FilterConfig.type":                   "The type of the filter.",
+	"FilterConfig.value":                  "The regular expression that is matched against the output of the check.",
+	"NamesPathsCfg.names":                 "Regular expressions that are matched against the name of a file or directory.",
+	"NamesPathsCfg.paths":                 "Glob patterns that are matched against the path of a file or directory relative to the project directory.",
+}
+
+var (
+	namesPathsType = reflect.TypeOf(matcher.NamesPathsCfg{})
+	filterType     = reflect.TypeOf(v0.FilterConfig{})
+	mapSliceType   = reflect.TypeOf(yaml.MapSlice{})
+)
+
+// JSONSchema returns a JSON Schema (draft-07) for the plugin configuration. The schema for the "config" block of each
+// of the checks registered with the provided factory is the schema provided by the checker. The schema can be used by
+// editors to provide completion and validation for the configuration file. The properties of the schema are generated
+// from the fields of the configuration types, so the schema accepts exactly the fields that the configuration accepts.
+func JSONSchema(factory okgo.CheckerFactory) ([]byte, error) {
+	if factory == nil {
+		return nil, errors.Errorf("factory must be provided")
+	}
+
+	var checkerTypes []interface{}
+	checkProperties := make(jsonSchema)
+	definitions := jsonSchema{
+		"namesPaths": structSchema(namesPathsType, nil),
+		"filter": structSchema(filterType, jsonSchema{
+			"type": jsonSchema{"enum": []interface{}{string(v0.MessageFilterType)}},
+		}),
+	}
+	var aliasConfigSchemas []interface{}
+	for _, checkerType := range factory.Types() {
+		configSchema, err := checkerConfigSchema(factory, checkerType)
+		if err != nil {
+			return nil, err
+		}
+		checkerTypes = append(checkerTypes, string(checkerType))
+		definitionName := "check-" + string(checkerType)
+		// the asset of a check whose key is the type of a checker can only be that type
+		definitions[definitionName] = checkSchema(configSchema, jsonSchema{"const": string(checkerType)})
+		checkProperties[string(checkerType)] = jsonSchema{"$ref": "#/definitions/" + definitionName}
+		aliasConfigSchemas = append(aliasConfigSchemas, jsonSchema{
+			"if": jsonSchema{
+				"properties": jsonSchema{"asset": jsonSchema{"const": string(checkerType)}},
+			},
+			"then": jsonSchema{
+				"properties": jsonSchema{"config": configSchema},
+			},
+		})
+	}
+
+	aliasSchema := checkSchema(jsonSchema{"type": "object"}, jsonSchema{"enum": checkerTypes})
+	aliasSchema["required"] = []interface{}{"asset"}
+	if len(aliasConfigSchemas) > 0 {
+		aliasSchema["allOf"] = aliasConfigSchemas
+	}
+	definitions["alias"] = aliasSchema
+	definitions["checks"] = jsonSchema{
+		"description":          "The configuration for the checks. An entry whose key is not the type of a checker defines an alias that runs the checker specified by its \"asset\" field.",
+		"type":                 "object",
+		"properties":           checkProperties,
+		"additionalProperties": jsonSchema{"$ref": "#/definitions/alias"},
+	}
+
+	checksRef := jsonSchema{"$ref": "#/definitions/checks"}
+	schema := structSchema(reflect.TypeOf(v0.ProjectConfig{}), jsonSchema{
+		"checks": checksRef,
+		"profiles": jsonSchema{
+			"type": "object",
+			"additionalProperties": structSchema(reflect.TypeOf(v0.ProfileConfig{}), jsonSchema{
+				"overrides": checksRef,
+			}),
+		},
+	})
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "check-plugin configuration"
+	schema["definitions"] = definitions
+	schemaBytes, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal JSON schema")
+	}
+	return schemaBytes, nil
+}
+
+// checkerConfigSchema returns the schema for the "config" block of the specified checker. If the checker does not
+// provide a schema, returns a schema that accepts any mapping.
+func checkerConfigSchema(factory okgo.CheckerFactory, checkerType okgo.CheckerType) (interface{}, error) {
+	configSchema, err := okgo.CheckerConfigSchema(factory, checkerType)
+	if err != nil {
+		return nil, err
+	}
+	if configSchema == nil {
+		return jsonSchema{"type": "object"}, nil
+	}
+	if !json.Valid(configSchema) {
+		return nil, errors.Errorf("configuration schema for checker %q is not valid JSON", checkerType)
+	}
+	return configSchema, nil
+}
+
+// checkSchema returns the schema for the configuration of a check whose "config" block and "asset" field have the
+// provided schemas.
+func checkSchema(configSchema, assetSchema interface{}) jsonSchema {
+	nonNegative := jsonSchema{"type": "integer", "minimum": 0}
+	return structSchema(reflect.TypeOf(v0.CheckerConfig{}), jsonSchema{
+		"asset":      assetSchema,
+		"config":     configSchema,
+		"shards":     nonNegative,
+		"shard-size": nonNegative,
+	})
+}
+
+// structSchema returns the schema for an object whose properties are the fields of the provided struct type. The name
+// of each property is the YAML key of the field, and the schema of a property is the schema in the provided overrides
+// if it exists and the schema for the type of the field otherwise.
+func structSchema(typ reflect.Type, overrides jsonSchema) jsonSchema {
+	properties := make(jsonSchema)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		propertySchema, ok := overrides[name]
+		if !ok {
+			propertySchema = typeSchema(field.Type)
+		}
+		if description, ok := fieldDescriptions[typ.Name()+"."+name]; ok {
+			if propertyJSONSchema, ok := propertySchema.(jsonSchema); ok {
+				withDescription := jsonSchema{"description": description}
+				for k, v := range propertyJSONSchema {
+					withDescription[k] = v
+				}
+				propertySchema = withDescription
+			}
+		}
+		properties[name] = propertySchema
+	}
+	return jsonSchema{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           properties,
+	}
+}
+
+// typeSchema returns the schema for values of the provided type.
+func typeSchema(typ reflect.Type) jsonSchema {
+	switch typ {
+	case namesPathsType:
+		return jsonSchema{"$ref": "#/definitions/namesPaths"}
+	case filterType:
+		return jsonSchema{"$ref": "#/definitions/filter"}
+	case mapSliceType:
+		return jsonSchema{"type": "object"}
+	}
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return typeSchema(typ.Elem())
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return jsonSchema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return jsonSchema{"type": "number"}
+	case reflect.String:
+		return jsonSchema{"type": "string"}
+	case reflect.Slice:
+		return jsonSchema{"type": "array", "items": typeSchema(typ.Elem())}
+	case reflect.Map:
+		return jsonSchema{"type": "object", "additionalProperties": typeSchema(typ.Elem())}
+	case reflect.Struct:
+		return structSchema(typ, nil)
+	default:
+		return jsonSchema{}
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/palantir/godel/v2/pkg/versionedconfig"
+	"github.com/palantir/okgo/okgo"
+	v0 "github.com/palantir/okgo/okgo/config/internal/v0"
+	yamlv3 "go.yaml.in/yaml/v3"
+	"gopkg.in/yaml.v2"
+)
+
+// Validate validates the provided configuration and returns the problems that were found as issues whose path,
+// line and column identify the location of the problem in the configuration. The provided cfgPath is used as the path
+// of the returned issues. In addition to verifying that the configuration is well-formed, validation verifies that
+// all of the configured checks are registered with the factory, that the configuration for each check is accepted by
+// its checker and that all regular expressions and path patterns are valid. Returns an empty slice if the
+// configuration is valid.
+func Validate(cfgPath string, cfgBytes []byte, factory okgo.CheckerFactory) []okgo.Issue {
+	v := &validator{
+		cfgPath: cfgPath,
+		factory: factory,
+	}
+	v.validate(cfgBytes)
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		if v.issues[i].Line != v.issues[j].Line {
+			return v.issues[i].Line < v.issues[j].Line
+		}
+		return v.issues[i].Col < v.issues[j].Col
+	})
+	return v.issues
+}
+
+type validator struct {
+	cfgPath     string
+	factory     okgo.CheckerFactory
+	envExpander *envExpander
+	// definedChecks are the checks that are registered with the factory or defined by the configuration.
+	definedChecks map[okgo.CheckerType]struct{}
+	issues        []okgo.Issue
+}
+
+var yamlErrLineRegexp = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.+)$`)
+
+func (v *validator) validate(cfgBytes []byte) {
+	if versionedconfig.IsLegacyConfig(cfgBytes) {
+		v.addIssue(nil, "configuration is in the legacy format: run the upgrade-config task to upgrade it")
+		return
+	}
+
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(cfgBytes, &doc); err != nil {
+		if matches := yamlErrLineRegexp.FindStringSubmatch(err.Error()); matches != nil {
+			line, _ := strconv.Atoi(matches[1])
+			v.issues = append(v.issues, okgo.Issue{
+				Path:    v.cfgPath,
+				Line:    line,
+				Content: matches[2],
+			})
+			return
+		}
+		v.addIssue(nil, err.Error())
+		return
+	}
+	if len(doc.Content) == 0 {
+		// empty configuration is valid
+		return
+	}
+	root := doc.Content[0]
+
+	v.validateStructure(root, reflect.TypeOf(v0.ProjectConfig{}))
+	if len(v.issues) > 0 {
+		// do not perform semantic validation on a configuration that is not well-formed
+		return
+	}
+
+	var cfg v0.ProjectConfig
+	if err := decodeNode(root, &cfg); err != nil {
+		v.addIssue(root, err.Error())
+		return
+	}
+	v.envExpander = newEnvExpander(cfg.FailOnUndefinedEnv)
+
+	v.definedChecks = make(map[okgo.CheckerType]struct{})
+	for _, checkerType := range v.factory.Types() {
+		v.definedChecks[checkerType] = struct{}{}
+	}
+	for k := range cfg.Checks {
+		v.definedChecks[k] = struct{}{}
+	}
+
+	_, checksNode := mappingValue(root, "checks")
+	v.validateChecks(checksNode)
+	_, excludeNode := mappingValue(root, "exclude")
+	v.validateNamesPaths(excludeNode)
+
+	_, profilesNode := mappingValue(root, "profiles")
+	forEachMappingEntry(profilesNode, func(profileKey, profileVal *yamlv3.Node) {
+		_, profileChecksNode := mappingValue(profileVal, "checks")
+		if profileChecksNode != nil {
+			for _, checkNode := range profileChecksNode.Content {
+				if _, ok := v.definedChecks[okgo.CheckerType(checkNode.Value)]; !ok {
+					v.addIssue(checkNode, fmt.Sprintf("profile %q specifies unknown check %q", profileKey.Value, checkNode.Value))
+				}
+			}
+		}
+		_, overridesNode := mappingValue(profileVal, "overrides")
+		v.validateChecks(overridesNode)
+	})
+}
+
+// validateStructure verifies that the provided node can be decoded as the provided type and that all of the mapping
+// keys correspond to known fields.
+func (v *validator) validateStructure(node *yamlv3.Node, typ reflect.Type) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if node.Kind == yamlv3.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yamlv3.ScalarNode && node.ShortTag() == "!!null" {
+		return
+	}
+	if typ == reflect.TypeOf(yaml.MapSlice{}) {
+		if node.Kind != yamlv3.MappingNode {
+			v.addIssue(node, "value must be a mapping")
+		}
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if node.Kind != yamlv3.MappingNode {
+			v.addIssue(node, "value must be a mapping")
+			return
+		}
+		fields := yamlFields(typ)
+		forEachMappingEntry(node, func(keyNode, valNode *yamlv3.Node) {
+			fieldType, ok := fields[keyNode.Value]
+			if !ok {
+				var known []string
+				for k := range fields {
+					known = append(known, k)
+				}
+				sort.Strings(known)
+				v.addIssue(keyNode, fmt.Sprintf("unknown field %q (valid fields: %v)", keyNode.Value, known))
+				return
+			}
+			v.validateStructure(valNode, fieldType)
+		})
+	case reflect.Map:
+		if node.Kind != yamlv3.MappingNode {
+			v.addIssue(node, "value must be a mapping")
+			return
+		}
+		forEachMappingEntry(node, func(keyNode, valNode *yamlv3.Node) {
+			v.validateStructure(valNode, typ.Elem())
+		})
+	case reflect.Slice:
+		if node.Kind != yamlv3.SequenceNode {
+			v.addIssue(node, "value must be a sequence")
+			return
+		}
+		for _, item := range node.Content {
+			v.validateStructure(item, typ.Elem())
+		}
+	default:
+		if node.Kind != yamlv3.ScalarNode {
+			v.addIssue(node, fmt.Sprintf("value must be a %s", typ.Kind()))
+			return
+		}
+		if err := node.Decode(reflect.New(typ).Interface()); err != nil {
+			v.addIssue(node, fmt.Sprintf("invalid value %q: must be a %s", node.Value, typ.Kind()))
+		}
+	}
+}
+
+// validateChecks validates a mapping node whose keys are check names and whose values are check configurations.
+func (v *validator) validateChecks(checksNode *yamlv3.Node) {
+	forEachMappingEntry(checksNode, func(keyNode, valNode *yamlv3.Node) {
+		v.validateCheck(okgo.CheckerType(keyNode.Value), keyNode, valNode)
+	})
+}
+
+func (v *validator) validateCheck(checkName okgo.CheckerType, keyNode, valNode *yamlv3.Node) {
+	var cfg CheckerConfig
+	if err := decodeNode(valNode, (*v0.CheckerConfig)(&cfg)); err != nil {
+		v.addIssue(valNode, err.Error())
+		return
+	}
+	if _, configNode := mappingValue(valNode, "config"); configNode != nil {
+		cfg.Config = markQuotedStrings(cfg.Config, configNode).(yaml.MapSlice)
+	}
+
+	registered := v.isRegistered(checkName)
+	assetType := checkName
+	if cfg.Asset != "" && cfg.Asset != checkName {
+		assetKeyNode, assetValNode := mappingValue(valNode, "asset")
+		switch {
+		case registered:
+			v.addIssue(assetKeyNode, fmt.Sprintf("check %q cannot be an alias for %q because a checker of type %q is registered", checkName, cfg.Asset, checkName))
+			return
+		case !v.isRegistered(cfg.Asset):
+			v.addIssue(assetValNode, fmt.Sprintf("unknown asset %q for check %q (registered checks: %v)", cfg.Asset, checkName, v.factory.Types()))
+			return
+		}
+		assetType = cfg.Asset
+	} else if !registered {
+		v.addIssue(keyNode, fmt.Sprintf("unknown check %q (registered checks: %v)", checkName, v.factory.Types()))
+		return
+	}
+
+	configKeyNode, _ := mappingValue(valNode, "config")
+	if configKeyNode == nil {
+		configKeyNode = keyNode
+	}
+	if expandedCfg, err := v.envExpander.expandYAML(cfg.Config); err != nil {
+		v.addIssue(configKeyNode, fmt.Sprintf("invalid configuration for check %q: %v", checkName, err))
+	} else if _, err := newChecker(assetType, expandedCfg.(yaml.MapSlice), v.factory); err != nil {
+		v.addIssue(configKeyNode, fmt.Sprintf("invalid configuration for check %q: %v", checkName, err))
+	}
+
+	_, filtersNode := mappingValue(valNode, "filters")
+	if filtersNode != nil {
+		for _, filterNode := range filtersNode.Content {
+			filterTypeKeyNode, filterTypeNode := mappingValue(filterNode, "type")
+			if filterTypeNode != nil && filterTypeNode.Value != "" && v0.FilterType(filterTypeNode.Value) != v0.MessageFilterType {
+				v.addIssue(filterTypeKeyNode, fmt.Sprintf("unrecognized filter type %q", filterTypeNode.Value))
+				continue
+			}
+			_, filterValNode := mappingValue(filterNode, "value")
+			if filterValNode == nil {
+				continue
+			}
+			v.validateRegexp(filterValNode)
+		}
+	}
+
+	_, includeNode := mappingValue(valNode, "include")
+	v.validateNamesPaths(includeNode)
+	_, excludeNode := mappingValue(valNode, "exclude")
+	v.validateNamesPaths(excludeNode)
+
+	if err := cfg.verifySharding(); err != nil {
+		shardsKeyNode, _ := mappingValue(valNode, "shards")
+		if shardsKeyNode == nil {
+			shardsKeyNode, _ = mappingValue(valNode, "shard-size")
+		}
+		v.addIssue(shardsKeyNode, fmt.Sprintf("invalid configuration for check %q: %v", checkName, err))
+	}
+
+	_, dependsOnNode := mappingValue(valNode, "depends-on")
+	if dependsOnNode != nil {
+		for _, dependencyNode := range dependsOnNode.Content {
+			dependency := okgo.CheckerType(dependencyNode.Value)
+			if dependency == checkName {
+				v.addIssue(dependencyNode, fmt.Sprintf("check %q cannot depend on itself", checkName))
+			} else if _, ok := v.definedChecks[dependency]; !ok {
+				v.addIssue(dependencyNode, fmt.Sprintf("check %q depends on unknown check %q", checkName, dependency))
+			}
+		}
+	}
+}
+
+// validateNamesPaths validates a node that is a matcher.NamesPathsCfg.
+func (v *validator) validateNamesPaths(node *yamlv3.Node) {
+	_, namesNode := mappingValue(node, "names")
+	if namesNode != nil {
+		for _, nameNode := range namesNode.Content {
+			v.validateRegexp(nameNode)
+		}
+	}
+	_, pathsNode := mappingValue(node, "paths")
+	if pathsNode != nil {
+		for _, pathNode := range pathsNode.Content {
+			pattern, err := v.envExpander.expandString(pathNode.Value)
+			if err != nil {
+				v.addIssue(pathNode, err.Error())
+				continue
+			}
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				v.addIssue(pathNode, fmt.Sprintf("invalid path pattern %q: %v", pattern, err))
+			}
+		}
+	}
+}
+
+func (v *validator) validateRegexp(node *yamlv3.Node) {
+	expr, err := v.envExpander.expandString(node.Value)
+	if err != nil {
+		v.addIssue(node, err.Error())
+		return
+	}
+	if _, err := regexp.Compile(expr); err != nil {
+		v.addIssue(node, fmt.Sprintf("invalid regular expression %q: %v", expr, err))
+	}
+}
+
+func (v *validator) isRegistered(checkerType okgo.CheckerType) bool {
+	return slices.Contains(v.factory.Types(), checkerType)
+}
+
+// addIssue records an issue at the location of the provided node. If node is nil, the issue is recorded for the
+// configuration as a whole.
+func (v *validator) addIssue(node *yamlv3.Node, msg string) {
+	issue := okgo.Issue{
+		Path:    v.cfgPath,
+		Content: msg,
+	}
+	if node != nil {
+		issue.Line = node.Line
+		issue.Col = node.Column
+	}
+	v.issues = append(v.issues, issue)
+}
+
+// decodeNode decodes the provided node into the provided output using the same YAML library that is used to load
+// configuration (which is required to populate yaml.MapSlice values).
+func decodeNode(node *yamlv3.Node, out interface{}) error {
+	nodeBytes, err := yamlv3.Marshal(node)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(nodeBytes, out)
+}
+
+// mappingValue returns the key and value nodes for the provided key in the provided mapping node. Returns nil values if
+// the node is not a mapping or does not contain the key.
+func mappingValue(node *yamlv3.Node, key string) (*yamlv3.Node, *yamlv3.Node) {
+	var keyNode, valNode *yamlv3.Node
+	forEachMappingEntry(node, func(currKey, currVal *yamlv3.Node) {
+		if currKey.Value == key {
+			keyNode, valNode = currKey, currVal
+		}
+	})
+	return keyNode, valNode
+}
+
+func forEachMappingEntry(node *yamlv3.Node, fn func(keyNode, valNode *yamlv3.Node)) {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		valNode := node.Content[i+1]
+		if valNode.Kind == yamlv3.AliasNode {
+			valNode = valNode.Alias
+		}
+		fn(node.Content[i], valNode)
+	}
+}
+
+// yamlFields returns the YAML keys of the fields of the provided struct type mapped to the type of the field. Fields
+// of inlined structs are included.
+func yamlFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		tagParts := strings.Split(field.Tag.Get("yaml"), ",")
+		name := tagParts[0]
+		if name == "-" {
+			continue
+		}
+		inline := false
+		for _, flag := range tagParts[1:] {
+			if flag == "inline" {
+				inline = true
+			}
+		}
+		if inline && field.Type.Kind() == reflect.Struct {
+			for k, v := range yamlFields(field.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
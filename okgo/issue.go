@@ -23,24 +23,80 @@ type Issue struct {
 	Line    int    `json:"line"`
 	Col     int    `json:"col"`
 	Content string `json:"content"`
+	// EndLine and EndCol are the position of the end of the range of the issue. Optional.
+	EndLine int `json:"endLine,omitempty"`
+	EndCol  int `json:"endCol,omitempty"`
+	// Rule is the name of the rule that reported the issue (for example, the name of an analyzer). Optional.
+	Rule string `json:"rule,omitempty"`
+	// SuggestedFixes are the changes that can be made to resolve the issue. Optional.
+	SuggestedFixes []SuggestedFix `json:"suggestedFixes,omitempty"`
+	// Related are secondary locations that are relevant to the issue (for example, the other site involved in a
+	// conflicting operation). Optional.
+	Related []RelatedLocation `json:"related,omitempty"`
+	// Error is true if the issue describes an error that prevented the check from running properly rather than an issue
+	// with the checked code. Optional.
+	Error bool `json:"error,omitempty"`
 }
 
+// RelatedLocation is a secondary location that is relevant to an issue.
+type RelatedLocation struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Col     int    `json:"col"`
+	EndLine int    `json:"endLine,omitempty"`
+	EndCol  int    `json:"endCol,omitempty"`
+	Message string `json:"message"`
+}
+
+// SuggestedFix is a change that can be made to resolve an issue.
+type SuggestedFix struct {
+	// Message describes the fix.
+	Message string     `json:"message"`
+	Edits   []TextEdit `json:"edits"`
+}
+
+// TextEdit is the replacement of the content of a file between two byte offsets with new text.
+type TextEdit struct {
+	Path string `json:"path"`
+	// Offset is the byte offset of the start of the replaced content.
+	Offset int `json:"offset"`
+	// End is the byte offset of the end of the replaced content. If it is equal to Offset, the edit is an insertion.
+	End     int    `json:"end"`
+	NewText string `json:"newText"`
+}
+
+// IsEmpty returns true if the issue does not contain any information.
+func (issue *Issue) IsEmpty() bool {
+	return issue.Path == "" && issue.Line == 0 && issue.Col == 0 && issue.Content == "" && issue.EndLine == 0 && issue.EndCol == 0 &&
+		issue.Rule == "" && len(issue.SuggestedFixes) == 0 && len(issue.Related) == 0 && !issue.Error
+}
+
+// String returns the issue in the form "path:line:col: content". Each related location is written on its own line,
+// indented with a tab, in the same form.
 func (issue *Issue) String() string {
+	output := locationString(issue.Path, issue.Line, issue.Col, issue.Content)
+	for _, related := range issue.Related {
+		output += "\n\t" + locationString(related.Path, related.Line, related.Col, related.Message)
+	}
+	return output
+}
+
+func locationString(path string, line, col int, content string) string {
 	var output string
-	if issue.Path != "" {
-		output += fmt.Sprintf("%s:", issue.Path)
+	if path != "" {
+		output += fmt.Sprintf("%s:", path)
 	}
-	if issue.Line != 0 {
-		output += fmt.Sprintf("%d:", issue.Line)
+	if line != 0 {
+		output += fmt.Sprintf("%d:", line)
 	}
-	if issue.Col != 0 {
-		output += fmt.Sprintf("%d:", issue.Col)
+	if col != 0 {
+		output += fmt.Sprintf("%d:", col)
 	}
-	if issue.Content != "" {
+	if content != "" {
 		if output != "" {
 			output += " "
 		}
-		output += issue.Content
+		output += content
 	}
 	return output
 }
@@ -21,6 +21,20 @@ import (
 type ProjectParam struct {
 	ReleaseTag string
 	Checks     map[CheckerType]CheckerParam
+
+	// Directories specifies the parameters for checks that apply to the packages in specific directories. The
+	// parameters for a package are determined by the most specific directory that contains it: packages that are not
+	// in any of the directories use the parameters in Checks.
+	Directories []DirectoryParam
+}
+
+// DirectoryParam specifies the parameters for checks that apply to all of the packages in a directory and its
+// subdirectories.
+type DirectoryParam struct {
+	// Dir is the path to the directory. It is expressed in the same form as the package paths provided to checks
+	// (relative to the working directory).
+	Dir    string
+	Checks map[CheckerType]CheckerParam
 }
 
 type CheckerType string
@@ -32,11 +46,31 @@ func (a ByCheckerType) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a ByCheckerType) Less(i, j int) bool { return a[i] < a[j] }
 
 type CheckerParam struct {
+	// Asset is the type of the checker that provides the check. It is only set if the check is an alias for a checker
+	// of a different type.
+	Asset    CheckerType
 	Skip     bool
 	Priority *CheckerPriority
 	Checker  Checker
 	Filters  []Filter
-	Exclude  matcher.Matcher
+	// Include restricts the check to the packages and files that it matches. If nil, all packages and files are
+	// included.
+	Include matcher.Matcher
+	Exclude matcher.Matcher
+	// Tags are the tags for the check specified by configuration. They are used in addition to the tags advertised by
+	// the checker.
+	Tags []string
+	// DependsOn are the checks that must pass before the check is run. Dependencies on checks that are not being run
+	// are ignored.
+	DependsOn []CheckerType
+	// Shards is the number of concurrent invocations of the check that the packages are split across. If 0 and
+	// ShardSize is 0, the check is run in a single invocation. The number of shards that run at the same time is
+	// limited by the CPUs reserved for the check, and the Checker must support concurrent calls to its check if more
+	// than one shard runs at the same time.
+	Shards int
+	// ShardSize is the maximum number of packages checked by a single invocation of the check. If 0, the number of
+	// invocations is determined by Shards.
+	ShardSize int
 }
 
 type Filter interface {
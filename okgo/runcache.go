@@ -0,0 +1,63 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okgo
+
+import (
+	"context"
+	"sync"
+)
+
+// RunCache stores values that are shared by the checks of a single run (for example, packages that are loaded and
+// type-checked by in-process checkers). A run provides its RunCache to ContextCheckers through the context of the
+// check, and the stored values are released once the run completes. It is safe for concurrent use.
+type RunCache struct {
+	lock   sync.Mutex
+	values map[interface{}]interface{}
+}
+
+// NewRunCache returns a new empty RunCache.
+func NewRunCache() *RunCache {
+	return &RunCache{
+		values: make(map[interface{}]interface{}),
+	}
+}
+
+// Value returns the value stored for the provided key. If no value is stored for the key, the value returned by
+// newValue is stored and returned. Keys should be of an unexported type defined by the package that uses them to avoid
+// collisions.
+func (c *RunCache) Value(key interface{}, newValue func() interface{}) interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		value = newValue()
+		c.values[key] = value
+	}
+	return value
+}
+
+type runCacheContextKey struct{}
+
+// ContextWithRunCache returns a copy of the provided context that carries the provided RunCache.
+func ContextWithRunCache(ctx context.Context, cache *RunCache) context.Context {
+	return context.WithValue(ctx, runCacheContextKey{}, cache)
+}
+
+// RunCacheFromContext returns the RunCache carried by the provided context. Returns nil if the context does not carry
+// a RunCache (for example, if a check is run outside of a run).
+func RunCacheFromContext(ctx context.Context) *RunCache {
+	cache, _ := ctx.Value(runCacheContextKey{}).(*RunCache)
+	return cache
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trace records the timeline of an okgo run as spans that can be written as a Chrome trace-event file, which
+// can be viewed using chrome://tracing or Perfetto (https://ui.perfetto.dev). Spans are only recorded once recording
+// has been enabled using Enable, so tracing has no cost for runs that do not write a trace.
+package trace
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MainLane is the lane of the main goroutine.
+const MainLane = 0
+
+const tracePID = 1
+
+var (
+	lock      sync.Mutex
+	enabled   bool
+	start     = time.Now()
+	events    []event
+	nextLane  = MainLane + 1
+	laneNames = map[int]string{MainLane: "main"}
+)
+
+// Enable enables the recording of spans. Spans that end before recording is enabled are not recorded.
+func Enable() {
+	lock.Lock()
+	defer lock.Unlock()
+	enabled = true
+}
+
+// Enabled returns true if the recording of spans is enabled.
+func Enabled() bool {
+	lock.Lock()
+	defer lock.Unlock()
+	return enabled
+}
+
+// event is an event in the Chrome trace-event format. Timestamps and durations are in microseconds.
+type event struct {
+	Name string                 `json:"name"`
+	Cat  string                 `json:"cat,omitempty"`
+	Ph   string                 `json:"ph"`
+	Ts   int64                  `json:"ts"`
+	Dur  int64                  `json:"dur"`
+	PID  int                    `json:"pid"`
+	TID  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// Span is a span of time on a lane of the timeline.
+type Span struct {
+	name     string
+	category string
+	lane     int
+	start    time.Time
+	args     map[string]interface{}
+}
+
+// NewLane returns a new lane with the provided name. Spans on the same lane should not overlap unless they are nested.
+// The name of the lane is only recorded if recording is enabled.
+func NewLane(name string) int {
+	lock.Lock()
+	defer lock.Unlock()
+	lane := nextLane
+	nextLane++
+	if enabled {
+		laneNames[lane] = name
+	}
+	return lane
+}
+
+// Begin starts a span with the provided name and category on the specified lane. The span is recorded when End is
+// called.
+func Begin(lane int, category, name string) *Span {
+	return &Span{
+		name:     name,
+		category: category,
+		lane:     lane,
+		start:    time.Now(),
+	}
+}
+
+// SetArg sets an argument that is displayed with the span.
+func (s *Span) SetArg(key string, value interface{}) {
+	if s.args == nil {
+		s.args = make(map[string]interface{})
+	}
+	s.args[key] = value
+}
+
+// End records the span if recording is enabled.
+func (s *Span) End() {
+	end := time.Now()
+	lock.Lock()
+	defer lock.Unlock()
+	if !enabled {
+		return
+	}
+	events = append(events, event{
+		Name: s.name,
+		Cat:  s.category,
+		Ph:   "X",
+		Ts:   s.start.Sub(start).Microseconds(),
+		Dur:  end.Sub(s.start).Microseconds(),
+		PID:  tracePID,
+		TID:  s.lane,
+		Args: s.args,
+	})
+}
+
+// Write writes the spans that have been recorded to the provided writer in the Chrome trace-event JSON format.
+func Write(w io.Writer) error {
+	lock.Lock()
+	traceEvents := []event{{
+		Name: "process_name",
+		Ph:   "M",
+		PID:  tracePID,
+		Args: map[string]interface{}{"name": "okgo"},
+	}}
+	var lanes []int
+	for lane := range laneNames {
+		lanes = append(lanes, lane)
+	}
+	sort.Ints(lanes)
+	for _, lane := range lanes {
+		traceEvents = append(traceEvents, event{
+			Name: "thread_name",
+			Ph:   "M",
+			PID:  tracePID,
+			TID:  lane,
+			Args: map[string]interface{}{"name": laneNames[lane]},
+		})
+	}
+	traceEvents = append(traceEvents, events...)
+	lock.Unlock()
+
+	bytes, err := json.Marshal(struct {
+		TraceEvents     []event `json:"traceEvents"`
+		DisplayTimeUnit string  `json:"displayTimeUnit"`
+	}{
+		TraceEvents:     traceEvents,
+		DisplayTimeUnit: "ms",
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal trace")
+	}
+	if _, err := w.Write(bytes); err != nil {
+		return errors.Wrapf(err, "failed to write trace")
+	}
+	return nil
+}
+
+// WriteFile writes the spans that have been recorded to the specified file in the Chrome trace-event JSON format.
+func WriteFile(traceFile string) error {
+	f, err := os.Create(traceFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create trace file")
+	}
+	if err := Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close trace file")
+	}
+	return nil
+}
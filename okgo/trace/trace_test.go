@@ -0,0 +1,86 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpansRecordedOnlyWhenEnabled(t *testing.T) {
+	resetForTest(t)
+
+	lane := NewLane("disabled lane")
+	Begin(lane, "test", "disabled span").End()
+	assert.False(t, Enabled())
+	// only the metadata events for the process and the main lane are written
+	assert.Len(t, writeEvents(t), 2)
+
+	Enable()
+	assert.True(t, Enabled())
+	enabledLane := NewLane("enabled lane")
+	assert.NotEqual(t, lane, enabledLane)
+	span := Begin(enabledLane, "test", "enabled span")
+	span.SetArg("issues", 2)
+	span.End()
+
+	traceEvents := writeEvents(t)
+	require.Len(t, traceEvents, 4)
+	assert.Equal(t, "process_name", traceEvents[0].Name)
+	assert.Equal(t, "thread_name", traceEvents[1].Name)
+	assert.Equal(t, map[string]interface{}{"name": "main"}, traceEvents[1].Args)
+	assert.Equal(t, "thread_name", traceEvents[2].Name)
+	assert.Equal(t, enabledLane, traceEvents[2].TID)
+	assert.Equal(t, map[string]interface{}{"name": "enabled lane"}, traceEvents[2].Args)
+	assert.Equal(t, event{
+		Name: "enabled span",
+		Cat:  "test",
+		Ph:   "X",
+		Ts:   traceEvents[3].Ts,
+		Dur:  traceEvents[3].Dur,
+		PID:  tracePID,
+		TID:  enabledLane,
+		Args: map[string]interface{}{"issues": float64(2)},
+	}, traceEvents[3])
+}
+
+// writeEvents returns the events written by Write.
+func writeEvents(t *testing.T) []event {
+	buf := &bytes.Buffer{}
+	require.NoError(t, Write(buf))
+	var trace struct {
+		TraceEvents []event `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	return trace.TraceEvents
+}
+
+// resetForTest resets the recorded state and restores it once the test completes.
+func resetForTest(t *testing.T) {
+	lock.Lock()
+	defer lock.Unlock()
+	origEnabled, origStart, origEvents, origNextLane, origLaneNames := enabled, start, events, nextLane, laneNames
+	enabled, start, events, nextLane, laneNames = false, time.Now(), nil, MainLane+1, map[int]string{MainLane: "main"}
+	t.Cleanup(func() {
+		lock.Lock()
+		defer lock.Unlock()
+		enabled, start, events, nextLane, laneNames = origEnabled, origStart, origEvents, origNextLane, origLaneNames
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okgotester
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/gofiles"
+	"github.com/palantir/godel/v2/framework/pluginapitester"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAssetCheckTest(t *testing.T) {
+	binDir := t.TempDir()
+	pluginPath := filepath.Join(binDir, "check-plugin")
+	buildCmd := exec.Command("go", "build", "-o", pluginPath, "github.com/palantir/okgo")
+	output, err := buildCmd.CombinedOutput()
+	require.NoError(t, err, "Output: %s", string(output))
+
+	assetPath := filepath.Join(binDir, "foo-asset")
+	require.NoError(t, os.WriteFile(assetPath, []byte(`#!/bin/sh
+case "$1" in
+  type) printf '"foo"' ;;
+  priority) printf '0' ;;
+  verify-config) ;;
+  check) echo '{"path":"foo.go","line":3,"col":2,"content":"found an issue"}' ;;
+  *) echo "unknown command $1" >&2; exit 1 ;;
+esac
+`), 0755))
+
+	RunAssetCheckTest(t,
+		pluginapitester.NewPluginProvider(pluginPath),
+		pluginapitester.NewAssetProvider(assetPath),
+		"foo",
+		"",
+		[]AssetTestCase{
+			{
+				Name: "output of a single check does not include a summary table",
+				ConfigFiles: map[string]string{
+					"go.mod":                        "module foo\n",
+					"godel/config/godel.yml":        "",
+					"godel/config/check-plugin.yml": "",
+				},
+				Specs: []gofiles.GoFileSpec{
+					{
+						RelPath: "foo.go",
+						Src:     "package foo\n",
+					},
+				},
+				WantError: true,
+				WantOutput: `Running foo...
+foo.go:3:2: found an issue
+Finished foo
+Check(s) produced output: [foo]
+`,
+			},
+		},
+	)
+}